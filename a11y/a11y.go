@@ -0,0 +1,144 @@
+// Package a11y checks UI element sizes against accessibility guidelines: the
+// WCAG target size criteria, the Material and Apple minimum touch targets, and
+// minimum legible text sizes. Findings report the size in the guideline's
+// unit and the physical size in millimeters on the screen being checked.
+package a11y
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// Rule is a minimum size from an accessibility guideline.
+type Rule struct {
+	// Name identifies the guideline in findings.
+	Name string
+	// Min is the minimum width and height of a target, or the minimum font
+	// size of text.
+	Min pxconv.Length
+}
+
+// Target size rules. CSS pixels and iOS points are both dp.
+var (
+	// WCAGMinimum is WCAG 2.2 success criterion 2.5.8 (level AA): 24 by 24
+	// CSS pixels. The spacing exception is not evaluated.
+	WCAGMinimum = Rule{Name: "WCAG 2.5.8 Target Size (Minimum)", Min: pxconv.Length{Value: 24, Unit: pxconv.UnitDp}}
+	// WCAGEnhanced is WCAG success criterion 2.5.5 (level AAA): 44 by 44 CSS pixels.
+	WCAGEnhanced = Rule{Name: "WCAG 2.5.5 Target Size (Enhanced)", Min: pxconv.Length{Value: 44, Unit: pxconv.UnitDp}}
+	// Material is Material Design's 48 by 48 dp touch target.
+	Material = Rule{Name: "Material touch target", Min: pxconv.Length{Value: 48, Unit: pxconv.UnitDp}}
+	// Apple is the Human Interface Guidelines' 44 by 44 pt hit target.
+	Apple = Rule{Name: "Apple hit target", Min: pxconv.Length{Value: 44, Unit: pxconv.UnitDp}}
+)
+
+// TargetRules are the rules CheckTargets applies when none are given.
+var TargetRules = []Rule{WCAGMinimum, WCAGEnhanced, Material, Apple}
+
+// MinText is the default minimum text size, 12sp.
+var MinText = TextRule(12)
+
+// TextRule returns a rule that requires text of at least size.
+func TextRule(size pxconv.Sp) Rule {
+	return Rule{
+		Name: "Minimum text size",
+		Min:  pxconv.Length{Value: float32(size), Unit: pxconv.UnitSp},
+	}
+}
+
+// Target is a touch or pointer target. Its size may be in dp, px or any
+// other unit.
+type Target struct {
+	Name          string
+	Width, Height pxconv.Length
+}
+
+// Text is a run of text with its font size, usually in sp or px.
+type Text struct {
+	Name string
+	Size pxconv.Length
+}
+
+// Finding reports an element that is smaller than a rule allows.
+type Finding struct {
+	// Element is the name of the target or text.
+	Element string
+	// Rule is the rule that failed.
+	Rule Rule
+	// Width and Height are the element's size in the unit of Rule.Min.
+	// Text findings have no width, and Height is the font size.
+	Width, Height float32
+	// WidthMm and HeightMm are the physical size on the checked screen.
+	WidthMm, HeightMm pxconv.Mm
+}
+
+// String describes the finding, for example
+// `"close": 40x40dp (6.35x6.35mm), Material touch target requires 48dp`.
+func (f Finding) String() string {
+	unit := f.Rule.Min.Unit.String()
+	if f.Width == 0 && f.WidthMm == 0 {
+		return fmt.Sprintf("%q: %s%s (%smm), %s requires %s",
+			f.Element, format(f.Height), unit, format(float32(f.HeightMm)), f.Rule.Name, f.Rule.Min)
+	}
+	return fmt.Sprintf("%q: %sx%s%s (%sx%smm), %s requires %s",
+		f.Element, format(f.Width), format(f.Height), unit,
+		format(float32(f.WidthMm)), format(float32(f.HeightMm)), f.Rule.Name, f.Rule.Min)
+}
+
+// CheckTargets checks every target against the rules, or against TargetRules
+// if none are given. A target fails a rule if either side is smaller than the
+// rule's minimum. Findings are ordered by target, then by rule.
+func CheckTargets(m pxconv.Metric, targets []Target, rules ...Rule) []Finding {
+	if len(rules) == 0 {
+		rules = TargetRules
+	}
+	var out []Finding
+	for _, t := range targets {
+		for _, r := range rules {
+			w := m.ConvertLength(t.Width, r.Min.Unit).Value
+			h := m.ConvertLength(t.Height, r.Min.Unit).Value
+			if below(w, r.Min.Value) || below(h, r.Min.Value) {
+				out = append(out, Finding{
+					Element:  t.Name,
+					Rule:     r,
+					Width:    w,
+					Height:   h,
+					WidthMm:  mm(m, t.Width),
+					HeightMm: mm(m, t.Height),
+				})
+			}
+		}
+	}
+	return out
+}
+
+// CheckText checks the font size of every text against the rule, such as
+// MinText.
+func CheckText(m pxconv.Metric, texts []Text, rule Rule) []Finding {
+	var out []Finding
+	for _, t := range texts {
+		size := m.ConvertLength(t.Size, rule.Min.Unit).Value
+		if below(size, rule.Min.Value) {
+			out = append(out, Finding{Element: t.Name, Rule: rule, Height: size, HeightMm: mm(m, t.Size)})
+		}
+	}
+	return out
+}
+
+// below reports whether v is smaller than limit, ignoring float32 rounding
+// error from unit conversion.
+func below(v, limit float32) bool {
+	return v < limit-limit*1e-5
+}
+
+// mm returns the physical length of l on the screen described by m.
+func mm(m pxconv.Metric, l pxconv.Length) pxconv.Mm {
+	return pxconv.Mm(m.ConvertLength(l, pxconv.UnitMm).Value)
+}
+
+// format prints a number with at most two decimals.
+func format(v float32) string {
+	return strconv.FormatFloat(math.Round(float64(v)*100)/100, 'f', -1, 64)
+}
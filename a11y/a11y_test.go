@@ -0,0 +1,95 @@
+package a11y
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// dp and px build lengths for the test targets.
+func dp(v float32) pxconv.Length { return pxconv.Length{Value: v, Unit: pxconv.UnitDp} }
+func px(v float32) pxconv.Length { return pxconv.Length{Value: v, Unit: pxconv.UnitPx} }
+
+// TestCheckTargets checks findings against every default rule for targets in
+// dp and px, and the sizes reported in dp and mm.
+func TestCheckTargets(t *testing.T) {
+	m := pxconv.NewMetric(2, 2, 320)
+	targets := []Target{
+		{Name: "ok", Width: dp(48), Height: dp(48)},
+		{Name: "px ok", Width: px(96), Height: px(120)},
+		{Name: "close", Width: dp(40), Height: dp(40)},
+		{Name: "chip", Width: px(200), Height: px(40)},
+	}
+	res := CheckTargets(m, targets)
+
+	var names []string
+	for _, f := range res {
+		names = append(names, f.Element+"/"+f.Rule.Name)
+	}
+	expected := []string{
+		"close/" + WCAGEnhanced.Name,
+		"close/" + Material.Name,
+		"close/" + Apple.Name,
+		"chip/" + WCAGMinimum.Name,
+		"chip/" + WCAGEnhanced.Name,
+		"chip/" + Material.Name,
+		"chip/" + Apple.Name,
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("CheckTargets findings = %v; expected %v", names, expected)
+	}
+
+	chip := res[3]
+	if chip.Width != 100 || chip.Height != 20 {
+		t.Errorf("chip size = %vx%v; expected 100x20dp", chip.Width, chip.Height)
+	}
+	// 40px at 320dpi is 1/8 inch.
+	if chip.HeightMm != 3.175 {
+		t.Errorf("chip height = %vmm; expected 3.175", chip.HeightMm)
+	}
+}
+
+// TestCheckTargetsRules checks that only the given rules are applied and the
+// text of a finding.
+func TestCheckTargetsRules(t *testing.T) {
+	m := pxconv.NewMetric(1, 1, 160)
+	targets := []Target{{Name: "close", Width: dp(40), Height: dp(40)}}
+	res := CheckTargets(m, targets, Material)
+	if len(res) != 1 {
+		t.Fatalf("CheckTargets(Material) = %v; expected one finding", res)
+	}
+	expected := `"close": 40x40dp (6.35x6.35mm), Material touch target requires 48dp`
+	if s := res[0].String(); s != expected {
+		t.Errorf("String() = %s; expected %s", s, expected)
+	}
+	if res := CheckTargets(m, targets, WCAGMinimum); len(res) != 0 {
+		t.Errorf("CheckTargets(WCAGMinimum) = %v; expected none", res)
+	}
+}
+
+// TestCheckText checks text sizes in sp and px against MinText and a custom
+// TextRule.
+func TestCheckText(t *testing.T) {
+	m := pxconv.NewMetric(2, 2.6, 320)
+	texts := []Text{
+		{Name: "body", Size: pxconv.Length{Value: 14, Unit: pxconv.UnitSp}},
+		{Name: "caption", Size: pxconv.Length{Value: 10, Unit: pxconv.UnitSp}},
+		{Name: "legal", Size: px(32)},
+		{Name: "badge", Size: px(24)},
+	}
+	res := CheckText(m, texts, MinText)
+	if len(res) != 2 || res[0].Element != "caption" || res[1].Element != "badge" {
+		t.Fatalf("CheckText(MinText) = %v; expected caption and badge", res)
+	}
+	if res[0].Height != 10 || res[0].Width != 0 {
+		t.Errorf("caption size = %vx%v; expected 0x10sp", res[0].Width, res[0].Height)
+	}
+	expected := `"caption": 10sp (2.06mm), Minimum text size requires 12sp`
+	if s := res[0].String(); s != expected {
+		t.Errorf("String() = %s; expected %s", s, expected)
+	}
+	if res := CheckText(m, texts, TextRule(9)); len(res) != 0 {
+		t.Errorf("CheckText(TextRule(9)) = %v; expected none", res)
+	}
+}
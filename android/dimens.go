@@ -0,0 +1,184 @@
+package android
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// ErrCycle is returned by ParseDimens when @dimen/ references form a cycle.
+var ErrCycle = errors.New("android: dimen reference cycle")
+
+// dimenRef is the prefix of a reference to another dimension.
+const dimenRef = "@dimen/"
+
+// Dimen is a named dimension of a dimens.xml file.
+type Dimen struct {
+	Name  string
+	Value pxconv.Length
+}
+
+// DpDimen returns a dimension in dp.
+func DpDimen(name string, value pxconv.Dp) Dimen {
+	return Dimen{Name: name, Value: pxconv.Length{Value: float32(value), Unit: pxconv.UnitDp}}
+}
+
+// SpDimen returns a dimension in sp.
+func SpDimen(name string, value pxconv.Sp) Dimen {
+	return Dimen{Name: name, Value: pxconv.Length{Value: float32(value), Unit: pxconv.UnitSp}}
+}
+
+// WriteDimens writes dimens as a dimens.xml resources file, in the given order.
+func WriteDimens(w io.Writer, dimens []Dimen) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString("<resources>\n")
+	for _, d := range dimens {
+		if err := validName(d.Name); err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "    <dimen name=%q>%s</dimen>\n", d.Name, d.Value)
+	}
+	b.WriteString("</resources>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// GenerateDimens renders dimens.xml files: base goes to values/dimens.xml, and
+// each entry of overrides, keyed by its qualifiers such as "sw600dp" or
+// "sw600dp-land", goes to values-<qualifiers>/dimens.xml. Overrides only need
+// the dimensions that change, since Android falls back to the base file for
+// the rest. The result maps file paths to contents.
+func GenerateDimens(base []Dimen, overrides map[string][]Dimen) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(overrides)+1)
+	names := make(map[string]bool, len(base))
+	for _, d := range base {
+		names[d.Name] = true
+	}
+
+	qualifiers := make([]string, 0, len(overrides))
+	for q := range overrides {
+		qualifiers = append(qualifiers, q)
+	}
+	sort.Strings(qualifiers)
+
+	var b bytes.Buffer
+	if err := WriteDimens(&b, base); err != nil {
+		return nil, err
+	}
+	files["values/dimens.xml"] = append([]byte(nil), b.Bytes()...)
+
+	for _, q := range qualifiers {
+		dir := "values-" + q
+		if _, err := ParseDir(dir); err != nil {
+			return nil, err
+		}
+		for _, d := range overrides[q] {
+			if !names[d.Name] {
+				return nil, fmt.Errorf("%w: %s overrides %q, which is missing from the base values", ErrSyntax, dir, d.Name)
+			}
+		}
+		b.Reset()
+		if err := WriteDimens(&b, overrides[q]); err != nil {
+			return nil, err
+		}
+		files[dir+"/dimens.xml"] = append([]byte(nil), b.Bytes()...)
+	}
+	return files, nil
+}
+
+// ParseDimens reads a dimens.xml file. Both <dimen> elements and <item
+// type="dimen"> elements are read, and @dimen/ references are resolved to the
+// referenced length. Unknown references and reference cycles are reported with
+// the names involved. The result keeps the order of the file.
+func ParseDimens(r io.Reader) ([]Dimen, error) {
+	var doc struct {
+		Dimens []struct {
+			XMLName xml.Name
+			Name    string `xml:"name,attr"`
+			Type    string `xml:"type,attr"`
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+
+	var order []string
+	raw := make(map[string]string)
+	for _, d := range doc.Dimens {
+		if d.XMLName.Local != "dimen" && (d.XMLName.Local != "item" || d.Type != "dimen") {
+			continue
+		}
+		if err := validName(d.Name); err != nil {
+			return nil, err
+		}
+		if _, dup := raw[d.Name]; dup {
+			return nil, fmt.Errorf("%w: duplicate dimen %q", ErrSyntax, d.Name)
+		}
+		order = append(order, d.Name)
+		raw[d.Name] = strings.TrimSpace(d.Value)
+	}
+
+	resolved := make(map[string]pxconv.Length, len(raw))
+	var resolve func(name string, path []string) (pxconv.Length, error)
+	resolve = func(name string, path []string) (pxconv.Length, error) {
+		if l, ok := resolved[name]; ok {
+			return l, nil
+		}
+		for i, p := range path {
+			if p == name {
+				return pxconv.Length{}, fmt.Errorf("%w: %s", ErrCycle, strings.Join(append(path[i:], name), " -> "))
+			}
+		}
+		value, ok := raw[name]
+		if !ok {
+			return pxconv.Length{}, fmt.Errorf("%w: %q references unknown dimen %q", ErrSyntax, path[len(path)-1], name)
+		}
+
+		var l pxconv.Length
+		var err error
+		if ref, isRef := strings.CutPrefix(value, dimenRef); isRef {
+			l, err = resolve(ref, append(path, name))
+		} else if l, err = pxconv.ParseLength(value); err != nil {
+			err = fmt.Errorf("%w: dimen %q: %w", ErrSyntax, name, err)
+		}
+		if err != nil {
+			return pxconv.Length{}, err
+		}
+		resolved[name] = l
+		return l, nil
+	}
+
+	dimens := make([]Dimen, 0, len(order))
+	for _, name := range order {
+		l, err := resolve(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		dimens = append(dimens, Dimen{Name: name, Value: l})
+	}
+	return dimens, nil
+}
+
+// validName reports an error unless name is a valid resource name.
+func validName(name string) error {
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '.'):
+		default:
+			return fmt.Errorf("%w: invalid resource name %q", ErrSyntax, name)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("%w: empty resource name", ErrSyntax)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package android
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestWriteDimens checks the generated XML.
+func TestWriteDimens(t *testing.T) {
+	var b bytes.Buffer
+	err := WriteDimens(&b, []Dimen{DpDimen("spacing_md", 16), SpDimen("text_body", 14.5)})
+	if err != nil {
+		t.Fatalf("WriteDimens returned %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<resources>
+    <dimen name="spacing_md">16dp</dimen>
+    <dimen name="text_body">14.5sp</dimen>
+</resources>
+`
+	if b.String() != expected {
+		t.Errorf("WriteDimens =\n%s\nexpected\n%s", b.String(), expected)
+	}
+	if err := WriteDimens(&b, []Dimen{DpDimen("1st", 1)}); !errors.Is(err, ErrSyntax) {
+		t.Errorf("WriteDimens with invalid name error = %v; expected ErrSyntax", err)
+	}
+}
+
+// TestGenerateDimens checks the file layout and override validation.
+func TestGenerateDimens(t *testing.T) {
+	base := []Dimen{DpDimen("gutter", 16), DpDimen("margin", 8)}
+	files, err := GenerateDimens(base, map[string][]Dimen{
+		"sw600dp":      {DpDimen("gutter", 24)},
+		"sw600dp-land": {DpDimen("gutter", 32)},
+	})
+	if err != nil {
+		t.Fatalf("GenerateDimens returned %v", err)
+	}
+
+	if len(files) != 3 {
+		t.Errorf("GenerateDimens produced %d files; expected 3", len(files))
+	}
+	if res := string(files["values-sw600dp-land/dimens.xml"]); !strings.Contains(res, `<dimen name="gutter">32dp</dimen>`) {
+		t.Errorf("values-sw600dp-land/dimens.xml =\n%s", res)
+	}
+
+	if _, err := GenerateDimens(base, map[string][]Dimen{"en": nil}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GenerateDimens with bad qualifier error = %v; expected ErrUnsupported", err)
+	}
+	if _, err := GenerateDimens(base, map[string][]Dimen{"land": {DpDimen("other", 1)}}); !errors.Is(err, ErrSyntax) {
+		t.Errorf("GenerateDimens with unknown override error = %v; expected ErrSyntax", err)
+	}
+}
+
+// TestParseDimens checks parsing, reference resolution and the roundtrip.
+func TestParseDimens(t *testing.T) {
+	src := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <dimen name="base">8dp</dimen>
+    <dimen name="double">@dimen/alias</dimen>
+    <item name="alias" type="dimen">@dimen/base</item>
+    <dimen name="text">14sp</dimen>
+    <string name="ignored">text</string>
+</resources>`
+
+	dimens, err := ParseDimens(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseDimens returned %v", err)
+	}
+	expected := []Dimen{DpDimen("base", 8), DpDimen("double", 8), DpDimen("alias", 8), SpDimen("text", 14)}
+	if !reflect.DeepEqual(dimens, expected) {
+		t.Errorf("ParseDimens = %v; expected %v", dimens, expected)
+	}
+
+	var b bytes.Buffer
+	if err := WriteDimens(&b, dimens); err != nil {
+		t.Fatalf("WriteDimens returned %v", err)
+	}
+	if again, err := ParseDimens(&b); err != nil || !reflect.DeepEqual(again, expected) {
+		t.Errorf("roundtrip = %v, %v; expected %v", again, err, expected)
+	}
+}
+
+// TestParseDimensErrors checks that broken files are reported clearly.
+func TestParseDimensErrors(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected error
+		message  string
+	}{
+		{`<resources><dimen name="a">@dimen/b</dimen><dimen name="b">@dimen/a</dimen></resources>`, ErrCycle, "a -> b -> a"},
+		{`<resources><dimen name="a">@dimen/a</dimen></resources>`, ErrCycle, "a -> a"},
+		{`<resources><dimen name="a">@dimen/missing</dimen></resources>`, ErrSyntax, `unknown dimen "missing"`},
+		{`<resources><dimen name="a">16em</dimen></resources>`, pxconv.ErrInvalidLength, `dimen "a"`},
+		{`<resources><dimen name="a">1dp</dimen><dimen name="a">2dp</dimen></resources>`, ErrSyntax, "duplicate"},
+		{`<resources><dimen name="a">1dp</resources>`, ErrSyntax, ""},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDimens(strings.NewReader(test.src))
+		if !errors.Is(err, test.expected) {
+			t.Errorf("ParseDimens(%s) error = %v; expected %v", test.src, err, test.expected)
+		}
+		if err != nil && !strings.Contains(err.Error(), test.message) {
+			t.Errorf("ParseDimens(%s) error = %q; expected it to mention %q", test.src, err, test.message)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+// Package android works with Android resources: it parses resource directory
+// qualifiers and predicts which directory a device loads, and it reads and
+// writes dimens.xml files.
+package android
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+var (
+	// ErrSyntax is returned for a malformed qualifier or file.
+	ErrSyntax = errors.New("android: syntax error")
+	// ErrUnsupported is returned for a qualifier outside the supported subset.
+	ErrUnsupported = errors.New("android: unsupported qualifier")
+	// ErrNoMatch is returned by Resolve when no directory matches the device.
+	ErrNoMatch = errors.New("android: no matching resource directory")
+)
+
+// Density values in dpi, as used by the density qualifier.
+const (
+	DensityLow     = 120
+	DensityMedium  = 160
+	DensityTV      = 213
+	DensityHigh    = 240
+	DensityXHigh   = 320
+	DensityXXHigh  = 480
+	DensityXXXHigh = 640
+	// DensityAny is anydpi: the resource suits every density and always wins.
+	DensityAny = 0xfffe
+	// DensityNone is nodpi: the resource is never scaled.
+	DensityNone = 0xffff
+)
+
+var densityNames = map[string]int{
+	"ldpi":    DensityLow,
+	"mdpi":    DensityMedium,
+	"tvdpi":   DensityTV,
+	"hdpi":    DensityHigh,
+	"xhdpi":   DensityXHigh,
+	"xxhdpi":  DensityXXHigh,
+	"xxxhdpi": DensityXXXHigh,
+	"anydpi":  DensityAny,
+	"nodpi":   DensityNone,
+}
+
+// Orientation is the screen orientation qualifier.
+type Orientation uint8
+
+const (
+	// OrientationAny means the qualifier is not set.
+	OrientationAny Orientation = iota
+	// Portrait is the port qualifier.
+	Portrait
+	// Landscape is the land qualifier.
+	Landscape
+)
+
+// NightMode is the night mode qualifier.
+type NightMode uint8
+
+const (
+	// NightAny means the qualifier is not set.
+	NightAny NightMode = iota
+	// Night is the night qualifier.
+	Night
+	// NotNight is the notnight qualifier.
+	NotNight
+)
+
+// Qualifiers are the configuration qualifiers of a resource directory such as
+// values-sw600dp-land-xhdpi. Zero fields mean the qualifier is not set.
+type Qualifiers struct {
+	// Type is the resource type, such as "values" or "drawable".
+	Type string
+	// SmallestWidth is the swNdp qualifier in dp.
+	SmallestWidth int
+	// Width is the wNdp qualifier in dp.
+	Width int
+	// Height is the hNdp qualifier in dp.
+	Height int
+	// Orientation is the port or land qualifier.
+	Orientation Orientation
+	// Night is the night or notnight qualifier.
+	Night NightMode
+	// Density is the density qualifier in dpi, including DensityAny and DensityNone.
+	Density int
+}
+
+// Qualifier ranks in Android's precedence order. Directory names must list
+// qualifiers in this order.
+const (
+	rankSmallestWidth = iota + 1
+	rankWidth
+	rankHeight
+	rankOrientation
+	rankNight
+	rankDensity
+)
+
+// ParseDir parses a resource directory name such as "values-sw600dp-land-xhdpi".
+// It supports the smallest-width, width, height, orientation, night and density
+// qualifiers, which must appear in Android's precedence order.
+func ParseDir(name string) (Qualifiers, error) {
+	parts := strings.Split(strings.ToLower(name), "-")
+	q := Qualifiers{Type: parts[0]}
+	if q.Type == "" {
+		return q, fmt.Errorf("%w: missing resource type in %q", ErrSyntax, name)
+	}
+
+	last := 0
+	for _, part := range parts[1:] {
+		rank, err := q.set(part)
+		if err != nil {
+			return q, fmt.Errorf("%w in %q", err, name)
+		}
+		if rank <= last {
+			return q, fmt.Errorf("%w: qualifier %q out of order in %q", ErrSyntax, part, name)
+		}
+		last = rank
+	}
+	return q, nil
+}
+
+// set applies one qualifier and returns its precedence rank.
+func (q *Qualifiers) set(part string) (int, error) {
+	switch part {
+	case "port":
+		q.Orientation = Portrait
+		return rankOrientation, nil
+	case "land":
+		q.Orientation = Landscape
+		return rankOrientation, nil
+	case "night":
+		q.Night = Night
+		return rankNight, nil
+	case "notnight":
+		q.Night = NotNight
+		return rankNight, nil
+	}
+	if d, ok := densityNames[part]; ok {
+		q.Density = d
+		return rankDensity, nil
+	}
+	if n, ok := parseNumber(part, "", "dpi"); ok {
+		q.Density = n
+		return rankDensity, nil
+	}
+	if n, ok := parseNumber(part, "sw", "dp"); ok {
+		q.SmallestWidth = n
+		return rankSmallestWidth, nil
+	}
+	if n, ok := parseNumber(part, "w", "dp"); ok {
+		q.Width = n
+		return rankWidth, nil
+	}
+	if n, ok := parseNumber(part, "h", "dp"); ok {
+		q.Height = n
+		return rankHeight, nil
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnsupported, part)
+}
+
+// parseNumber parses qualifiers such as "sw600dp" or "420dpi": the prefix,
+// a positive decimal number and the suffix.
+func parseNumber(part, prefix, suffix string) (int, bool) {
+	digits, ok := strings.CutPrefix(part, prefix)
+	if !ok {
+		return 0, false
+	}
+	if digits, ok = strings.CutSuffix(digits, suffix); !ok || digits == "" {
+		return 0, false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// String returns the directory name for the qualifiers.
+func (q Qualifiers) String() string {
+	parts := []string{q.Type}
+	if q.SmallestWidth > 0 {
+		parts = append(parts, "sw"+strconv.Itoa(q.SmallestWidth)+"dp")
+	}
+	if q.Width > 0 {
+		parts = append(parts, "w"+strconv.Itoa(q.Width)+"dp")
+	}
+	if q.Height > 0 {
+		parts = append(parts, "h"+strconv.Itoa(q.Height)+"dp")
+	}
+	switch q.Orientation {
+	case Portrait:
+		parts = append(parts, "port")
+	case Landscape:
+		parts = append(parts, "land")
+	default:
+		// OrientationAny adds no qualifier.
+	}
+	switch q.Night {
+	case Night:
+		parts = append(parts, "night")
+	case NotNight:
+		parts = append(parts, "notnight")
+	default:
+		// NightAny adds no qualifier.
+	}
+	if q.Density > 0 {
+		parts = append(parts, densityName(q.Density))
+	}
+	return strings.Join(parts, "-")
+}
+
+func densityName(d int) string {
+	for name, v := range densityNames {
+		if v == d {
+			return name
+		}
+	}
+	return strconv.Itoa(d) + "dpi"
+}
+
+// Device describes the configuration a device reports to the resource system.
+type Device struct {
+	// Metric supplies the density: densityDpi is 160 * PxPerDp.
+	Metric pxconv.Metric
+	// Width and Height are the available screen size in dp in the current orientation.
+	Width, Height pxconv.Dp
+	// Night reports whether night mode is on.
+	Night bool
+}
+
+// DensityDpi returns the density bucket value the device reports, 160 * PxPerDp.
+func (d Device) DensityDpi() int {
+	return int(density.EnsurePositive(d.Metric.PxPerDp)*DensityMedium + 0.5)
+}
+
+// Orientation returns Portrait when the screen is at least as tall as it is wide.
+func (d Device) Orientation() Orientation {
+	if d.Height >= d.Width {
+		return Portrait
+	}
+	return Landscape
+}
+
+// Resolve returns the directory among dirs that Android picks for the device.
+// It follows Android's algorithm: directories that contradict the device are
+// eliminated, then each qualifier in precedence order narrows the candidates
+// (for sw, w and h, the largest value that does not exceed the device wins), and
+// finally the best density is chosen, preferring to scale larger assets down.
+// The directories should share a resource type.
+//
+// Directories with qualifiers outside the supported subset, such as
+// values-v21 or values-en, are skipped, so the prediction is made among the
+// directories that can be evaluated. A malformed directory name is an error.
+func Resolve(dirs []string, dev Device) (string, error) {
+	type candidate struct {
+		dir string
+		q   Qualifiers
+	}
+	var candidates []candidate
+	for _, dir := range dirs {
+		q, err := ParseDir(dir)
+		if errors.Is(err, ErrUnsupported) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if q.matches(dev) {
+			candidates = append(candidates, candidate{dir, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", ErrNoMatch
+	}
+
+	// Narrow by each qualifier in precedence order, keeping the best value.
+	keys := []func(Qualifiers) int{
+		func(q Qualifiers) int { return q.SmallestWidth },
+		func(q Qualifiers) int { return q.Width },
+		func(q Qualifiers) int { return q.Height },
+		func(q Qualifiers) int { return int(q.Orientation) },
+		func(q Qualifiers) int { return int(q.Night) },
+	}
+	for _, key := range keys {
+		best := 0
+		for _, c := range candidates {
+			best = max(best, key(c.q))
+		}
+		if best == 0 {
+			continue
+		}
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if key(c.q) == best {
+				kept = append(kept, c)
+			}
+		}
+		candidates = kept
+	}
+
+	best := candidates[0]
+	req := dev.DensityDpi()
+	for _, c := range candidates[1:] {
+		if betterDensity(c.q.Density, best.q.Density, req) {
+			best = c
+		}
+	}
+	return best.dir, nil
+}
+
+// matches reports whether the qualifiers do not contradict the device.
+// The density qualifier never eliminates a directory.
+func (q Qualifiers) matches(dev Device) bool {
+	sw := min(dev.Width, dev.Height)
+	switch {
+	case q.SmallestWidth > 0 && pxconv.Dp(q.SmallestWidth) > sw:
+		return false
+	case q.Width > 0 && pxconv.Dp(q.Width) > dev.Width:
+		return false
+	case q.Height > 0 && pxconv.Dp(q.Height) > dev.Height:
+		return false
+	case q.Orientation != OrientationAny && q.Orientation != dev.Orientation():
+		return false
+	case q.Night == Night && !dev.Night, q.Night == NotNight && dev.Night:
+		return false
+	}
+	return true
+}
+
+// betterDensity reports whether density a is a better match than b for the
+// requested density, following ResTable_config::isBetterThan in AOSP.
+func betterDensity(a, b, req int) bool {
+	if a == 0 {
+		a = DensityMedium
+	}
+	if b == 0 {
+		b = DensityMedium
+	}
+	if a == b {
+		return false
+	}
+	if a == DensityAny || b == DensityAny {
+		return a == DensityAny
+	}
+
+	h, l, aBigger := a, b, true
+	if a < b {
+		h, l, aBigger = b, a, false
+	}
+	switch {
+	case req >= h:
+		// Both are lower than requested: take the higher one.
+		return aBigger
+	case l >= req:
+		// Both are higher than requested: take the lower one.
+		return !aBigger
+	case (2*l-req)*h > req*req:
+		// Between the two: the lower one is close enough.
+		return !aBigger
+	default:
+		return aBigger
+	}
+}
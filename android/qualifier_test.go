@@ -0,0 +1,153 @@
+package android
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestParseDir checks parsing of supported qualifiers and the String roundtrip.
+func TestParseDir(t *testing.T) {
+	tests := []struct {
+		dir      string
+		expected Qualifiers
+	}{
+		{"values", Qualifiers{Type: "values"}},
+		{"values-sw600dp-land-xhdpi", Qualifiers{Type: "values", SmallestWidth: 600, Orientation: Landscape, Density: DensityXHigh}},
+		{"drawable-w820dp-h480dp-port-night-420dpi", Qualifiers{Type: "drawable", Width: 820, Height: 480, Orientation: Portrait, Night: Night, Density: 420}},
+		{"mipmap-notnight-anydpi", Qualifiers{Type: "mipmap", Night: NotNight, Density: DensityAny}},
+		{"drawable-nodpi", Qualifiers{Type: "drawable", Density: DensityNone}},
+	}
+
+	for _, test := range tests {
+		q, err := ParseDir(test.dir)
+		if err != nil {
+			t.Errorf("ParseDir(%q) returned %v", test.dir, err)
+			continue
+		}
+		if q != test.expected {
+			t.Errorf("ParseDir(%q) = %+v; expected %+v", test.dir, q, test.expected)
+		}
+		if res := q.String(); res != test.dir {
+			t.Errorf("ParseDir(%q).String() = %q", test.dir, res)
+		}
+	}
+}
+
+// TestParseDirErrors checks that malformed and unsupported names are rejected.
+func TestParseDirErrors(t *testing.T) {
+	tests := []struct {
+		dir      string
+		expected error
+	}{
+		{"-hdpi", ErrSyntax},
+		{"values-hdpi-land", ErrSyntax},
+		{"values-w600dp-sw600dp", ErrSyntax},
+		{"values-en", ErrUnsupported},
+		{"values-sw600dpi", ErrUnsupported},
+		{"values-w0dp", ErrUnsupported},
+		{"values-w+5dp", ErrUnsupported},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseDir(test.dir); !errors.Is(err, test.expected) {
+			t.Errorf("ParseDir(%q) error = %v; expected %v", test.dir, err, test.expected)
+		}
+	}
+}
+
+// TestResolve checks best-match selection for typical devices.
+func TestResolve(t *testing.T) {
+	dirs := []string{
+		"values",
+		"values-land",
+		"values-sw600dp",
+		"values-sw720dp",
+		"values-sw600dp-land",
+		"values-w1000dp",
+		"values-night",
+	}
+	phone := Device{Metric: pxconv.NewMetric(2.625, 2.625, 420), Width: 411, Height: 914}
+	tablet := Device{Metric: pxconv.NewMetric(2, 2, 320), Width: 1024, Height: 600}
+
+	tests := []struct {
+		name     string
+		dev      Device
+		expected string
+	}{
+		{"phone portrait", phone, "values"},
+		{"phone landscape", Device{Metric: phone.Metric, Width: 914, Height: 411}, "values-land"},
+		{"phone night", Device{Metric: phone.Metric, Width: 411, Height: 914, Night: true}, "values-night"},
+		{"tablet landscape", tablet, "values-sw600dp-land"},
+		{"tablet portrait", Device{Metric: tablet.Metric, Width: 600, Height: 1024}, "values-sw600dp"},
+	}
+
+	for _, test := range tests {
+		res, err := Resolve(dirs, test.dev)
+		if err != nil || res != test.expected {
+			t.Errorf("%s: Resolve = %q, %v; expected %q", test.name, res, err, test.expected)
+		}
+	}
+}
+
+// TestResolveDensity checks density selection, which prefers scaling down.
+func TestResolveDensity(t *testing.T) {
+	tests := []struct {
+		dirs     []string
+		pxPerDp  float32
+		expected string
+	}{
+		{[]string{"drawable-mdpi", "drawable-hdpi", "drawable-xhdpi"}, 2, "drawable-xhdpi"},
+		{[]string{"drawable-mdpi", "drawable-xxhdpi"}, 2, "drawable-xxhdpi"},
+		{[]string{"drawable-mdpi", "drawable-hdpi"}, 3, "drawable-hdpi"},
+		{[]string{"drawable-hdpi", "drawable-xxxhdpi"}, 1.75, "drawable-hdpi"},
+		{[]string{"drawable-hdpi", "drawable-xxxhdpi"}, 2, "drawable-xxxhdpi"},
+		{[]string{"drawable", "drawable-hdpi"}, 1, "drawable"},
+		{[]string{"drawable-xxhdpi", "drawable-anydpi"}, 3, "drawable-anydpi"},
+		{[]string{"drawable-xhdpi", "drawable-420dpi"}, 2.625, "drawable-420dpi"},
+	}
+
+	for _, test := range tests {
+		dev := Device{Metric: pxconv.NewMetric(test.pxPerDp, test.pxPerDp, 160*test.pxPerDp), Width: 400, Height: 800}
+		res, err := Resolve(test.dirs, dev)
+		if err != nil || res != test.expected {
+			t.Errorf("Resolve(%v) at %vx = %q, %v; expected %q", test.dirs, test.pxPerDp, res, err, test.expected)
+		}
+	}
+}
+
+// TestResolveNoMatch checks the error when every directory contradicts the device.
+func TestResolveNoMatch(t *testing.T) {
+	dev := Device{Metric: pxconv.NewMetric(1, 1, 160), Width: 320, Height: 480}
+	if _, err := Resolve([]string{"values-sw600dp", "values-land"}, dev); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Resolve error = %v; expected ErrNoMatch", err)
+	}
+	if _, err := Resolve([]string{"values-xx"}, dev); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Resolve error = %v; expected ErrNoMatch", err)
+	}
+	if _, err := Resolve([]string{"values", "drawable-mdpi-port"}, dev); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Resolve error = %v; expected ErrSyntax", err)
+	}
+}
+
+// TestResolveSkipsUnsupported checks that a realistic res/ listing with
+// version and locale directories still resolves.
+func TestResolveSkipsUnsupported(t *testing.T) {
+	dirs := []string{"values", "values-v21", "values-en", "values-night", "values-sw600dp", "values-sw600dp-v28"}
+	tests := []struct {
+		dev      Device
+		expected string
+	}{
+		{Device{Metric: pxconv.NewMetric(2, 2, 320), Width: 400, Height: 800}, "values"},
+		{Device{Metric: pxconv.NewMetric(2, 2, 320), Width: 400, Height: 800, Night: true}, "values-night"},
+		{Device{Metric: pxconv.NewMetric(2, 2, 320), Width: 800, Height: 1280}, "values-sw600dp"},
+	}
+
+	for _, test := range tests {
+		res, err := Resolve(dirs, test.dev)
+		if err != nil || res != test.expected {
+			t.Errorf("Resolve(%+v) = %q, %v; expected %q", test.dev, res, err, test.expected)
+		}
+	}
+}
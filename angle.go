@@ -0,0 +1,70 @@
+package pxconv
+
+import "math"
+
+// Degree represents an angle in degrees, such as the visual angle an object
+// covers in the field of view.
+type Degree float32
+
+// Arcmin represents an angle in minutes of arc, 1/60 of a degree. Normal
+// visual acuity resolves about one arcminute.
+type Arcmin float32
+
+// Arcmin converts degrees to minutes of arc.
+func (a Degree) Arcmin() Arcmin {
+	return Arcmin(float64(a) * 60)
+}
+
+// Degree converts minutes of arc to degrees.
+func (a Arcmin) Degree() Degree {
+	return Degree(float64(a) / 60)
+}
+
+// Distance is the constraint for viewing distances and physical lengths in the
+// visual angle functions: Mm or Inch, so that the angle is computed from a
+// length that does not depend on screen density.
+type Distance interface {
+	Unit
+	Mm | Inch
+}
+
+// VisualSize returns the length of an object centered on the line of sight
+// that covers angle at the given viewing distance, 2·d·tan(angle/2). The
+// result is in the unit of distance, Mm or Inch:
+//
+//	size := pxconv.VisualSize(pxconv.Arcmin(30).Degree(), pxconv.Mm(600))
+func VisualSize[L Distance](angle Degree, distance L) L {
+	return L(2 * float64(distance) * math.Tan(radians(angle)/2))
+}
+
+// VisualAngle returns the angle covered by an object of the given size
+// centered on the line of sight at the given viewing distance, the inverse of
+// VisualSize. Size and distance share a unit.
+func VisualAngle[L Distance](size, distance L) Degree {
+	return Degree(2 * math.Atan(float64(size)/(2*float64(distance))) * 180 / math.Pi)
+}
+
+// AngleToPx returns how many pixels cover angle at the given viewing
+// distance, rounding to the nearest integer. The length is converted with
+// m.Dpi. For example, 1 degree at 2.5 m on a 40 dpi TV is about 69px.
+func AngleToPx[L Distance](m Metric, angle Degree, distance L) int {
+	return ToPx(m, VisualSize(angle, distance))
+}
+
+// PxToAngle returns the visual angle covered by a pixel length at the given
+// viewing distance.
+func PxToAngle[L Distance](m Metric, value int, distance L) Degree {
+	return VisualAngle(FromPx[L](m, value), distance)
+}
+
+// PixelsPerDegree returns the number of pixels covering one degree at the
+// center of view for a display with m.Dpi viewed from distance. About 60 pixels
+// per degree matches the one arcminute resolved by normal vision.
+func PixelsPerDegree[L Distance](m Metric, distance L) float32 {
+	return float32(To[Px](m, VisualSize(1, distance)))
+}
+
+// radians converts degrees to radians.
+func radians(a Degree) float64 {
+	return float64(a) * math.Pi / 180
+}
@@ -0,0 +1,76 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestArcmin checks conversions between degrees and minutes of arc.
+func TestArcmin(t *testing.T) {
+	if res := Degree(1.5).Arcmin(); res != 90 {
+		t.Errorf("Degree(1.5).Arcmin() = %v; expected 90", res)
+	}
+	if res := Arcmin(30).Degree(); res != 0.5 {
+		t.Errorf("Arcmin(30).Degree() = %v; expected 0.5", res)
+	}
+}
+
+// TestVisualSize checks lengths covered by an angle in mm and inches, and the
+// inverse VisualAngle.
+func TestVisualSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      float32
+		expected float32
+	}{
+		// A 90 degree field at distance d is 2d wide.
+		{"VisualSize(90, 500mm)", float32(VisualSize(90, Mm(500))), 1000},
+		// One arcminute at 20 feet is the stroke of a 20/20 Snellen letter, about 1.77mm.
+		{"VisualSize(1', 6096mm)", float32(VisualSize(Arcmin(1).Degree(), Mm(6096))), 1.7733},
+		{"VisualSize(60, 10in)", float32(VisualSize(60, Inch(10))), 11.547},
+		{"VisualAngle(1000mm, 500mm)", float32(VisualAngle(Mm(1000), Mm(500))), 90},
+	}
+
+	for _, test := range tests {
+		if math.Abs(float64(test.res-test.expected)) > 1e-3 {
+			t.Errorf("%s = %v; expected %v", test.name, test.res, test.expected)
+		}
+	}
+}
+
+// TestAngleToPx checks pixel conversions of visual angles through Dpi on a
+// TV and a phone.
+func TestAngleToPx(t *testing.T) {
+	tv := NewMetric(1, 1, 40)
+	// 2500mm * 2 * tan(0.5 deg) = 43.63mm = 1.7178in -> 68.7px.
+	if res := AngleToPx(tv, 1, Mm(2500)); res != 69 {
+		t.Errorf("AngleToPx(1, 2500mm) = %d; expected 69", res)
+	}
+	if res := AngleToPx(tv, 1, Inch(2500/25.4)); res != 69 {
+		t.Errorf("AngleToPx(1, %vin) = %d; expected 69", Inch(2500/25.4), res)
+	}
+	if res := PxToAngle(tv, 69, Mm(2500)); math.Abs(float64(res)-1.0045) > 1e-3 {
+		t.Errorf("PxToAngle(69, 2500mm) = %v; expected about 1.0045", res)
+	}
+	if res := PixelsPerDegree(tv, Mm(2500)); math.Abs(float64(res)-68.71) > 0.01 {
+		t.Errorf("PixelsPerDegree(2500mm) = %v; expected 68.71", res)
+	}
+	// A 326 dpi phone at 30 cm is well above 60 pixels per degree.
+	if res := PixelsPerDegree(NewMetric(2, 2, 326), Mm(300)); res < 60 {
+		t.Errorf("PixelsPerDegree(300mm) at 326 dpi = %v; expected more than 60", res)
+	}
+}
+
+// TestPropVisualAngleInverse checks that VisualAngle inverts VisualSize.
+func TestPropVisualAngleInverse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		angle := Degree(rapid.Float32Range(0.01, 170).Draw(t, "angle"))
+		distance := Mm(rapid.Float32Range(10, 10000).Draw(t, "distance"))
+		back := VisualAngle(VisualSize(angle, distance), distance)
+		if math.Abs(float64(back-angle)) > 1e-3*math.Max(1, float64(angle)) {
+			t.Fatalf("VisualAngle(VisualSize(%v)) = %v", angle, back)
+		}
+	})
+}
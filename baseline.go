@@ -0,0 +1,120 @@
+package pxconv
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+// Default font metrics used when Baseline.Ascent or Baseline.Descent is zero.
+// Together they make the content area exactly one em tall.
+const (
+	defaultAscent  = 0.8
+	defaultDescent = 0.2
+)
+
+// Baseline describes a text style set on a baseline grid.
+type Baseline struct {
+	// FontSize is the font size.
+	FontSize Sp
+	// LineHeight is the desired line height as a multiple of FontSize, such as 1.5.
+	// Zero or negative values are treated as 1.
+	LineHeight float32
+	// Grid is the baseline grid step, such as 4dp.
+	Grid Dp
+	// Ascent and Descent are the font's ascent and descent as fractions of
+	// FontSize. Zero values mean 0.8 and 0.2.
+	Ascent, Descent float32
+}
+
+// LineHeight is a line height snapped to the baseline grid for one Metric.
+// Pixel values are device pixels.
+type LineHeight struct {
+	// Sp is the snapped line height.
+	Sp Sp
+	// Px is the snapped line height, a whole multiple of GridPx.
+	Px int
+	// GridPx is the grid step, at least one pixel.
+	GridPx int
+	// PaddingTop moves the first baseline onto a grid line.
+	PaddingTop int
+	// PaddingBottom brings the total height back to a multiple of GridPx.
+	PaddingBottom int
+	// Error is Px minus the unsnapped line height, FontSize*LineHeight in pixels.
+	Error float32
+}
+
+// SnapLineHeight snaps the line height of b to the nearest whole number of
+// grid steps, at least one, and computes the padding that puts the first
+// baseline on the grid. The grid step is rounded to whole pixels first, so
+// lines stay on the grid at densities where the step is fractional.
+//
+// The first baseline sits half the leading plus the ascent below the top of
+// the line box, as in CSS. The font size is the rendered one, so
+// FontScalingNonlinear is taken into account.
+//
+// For example, 14sp text with a 1.5 line height on a 4dp grid at 1.5x gives
+// a 30px (20sp) line height, 1.5px short of the unsnapped 31.5px, with 3px of
+// padding above and below.
+func (c Metric) SnapLineHeight(b Baseline) LineHeight {
+	grid := c.DpToPx(b.Grid)
+	if grid < 1 {
+		grid = 1
+	}
+	// The rendered font size, which follows FontScaling.
+	font := float64(c.SpToDp(b.FontSize)) * float64(density.EnsurePositive(c.PxPerDp))
+	raw := font * float64(density.EnsurePositive(b.LineHeight))
+
+	steps := roundToInt(raw / float64(grid))
+	if steps < 1 {
+		steps = 1
+	}
+	px := steps * grid
+
+	ascent, descent := float64(b.Ascent), float64(b.Descent)
+	if ascent == 0 {
+		ascent = defaultAscent
+	}
+	if descent == 0 {
+		descent = defaultDescent
+	}
+	baseline := roundToInt((float64(px)-font*(ascent+descent))/2 + font*ascent)
+	top := mod(-baseline, grid)
+
+	return LineHeight{
+		Sp:            c.PxToSp(px),
+		Px:            px,
+		GridPx:        grid,
+		PaddingTop:    top,
+		PaddingBottom: mod(-top, grid),
+		Error:         float32(float64(px) - raw),
+	}
+}
+
+// SnapLineHeights snaps b for each Metric, such as every Android density
+// bucket, so the error introduced at each density can be compared.
+func SnapLineHeights(b Baseline, metrics ...Metric) []LineHeight {
+	out := make([]LineHeight, len(metrics))
+	for i, m := range metrics {
+		out[i] = m.SnapLineHeight(b)
+	}
+	return out
+}
+
+// MaxLineHeightError returns the largest absolute Error in lines, in pixels.
+func MaxLineHeightError(lines []LineHeight) float32 {
+	var worst float64
+	for _, l := range lines {
+		worst = math.Max(worst, math.Abs(float64(l.Error)))
+	}
+	return float32(worst)
+}
+
+// mod returns a modulo b in [0, b) for b > 0.
+func mod(a, b int) int {
+	r := a % b
+	if r < 0 {
+		r += b
+	}
+	return r
+}
@@ -0,0 +1,118 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestSnapLineHeight checks snapped line heights, padding and error at several
+// densities and font scales.
+func TestSnapLineHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        Metric
+		b        Baseline
+		expected LineHeight
+	}{
+		{
+			"1.5x",
+			NewMetric(1.5, 1.5, 240),
+			Baseline{FontSize: 14, LineHeight: 1.5, Grid: 4},
+			LineHeight{Sp: 20, Px: 30, GridPx: 6, PaddingTop: 3, PaddingBottom: 3, Error: -1.5},
+		},
+		{
+			"mdpi exact",
+			NewMetric(1, 1, 160),
+			Baseline{FontSize: 16, LineHeight: 1.5, Grid: 4},
+			LineHeight{Sp: 24, Px: 24, GridPx: 4, PaddingTop: 3, PaddingBottom: 1, Error: 0},
+		},
+		{
+			"font scale",
+			NewMetric(2, 2.6, 320),
+			Baseline{FontSize: 16, LineHeight: 1.4, Grid: 4, Ascent: 0.93, Descent: 0.24},
+			// 58.24px raw, 8px grid: 7.28 steps round to 7.
+			LineHeight{Sp: 56 / 2.6, Px: 56, GridPx: 8, PaddingTop: 6, PaddingBottom: 2, Error: -2.24},
+		},
+		{
+			"nonlinear",
+			NewMetric(1, 2, 160).WithFontScaling(FontScalingNonlinear),
+			Baseline{FontSize: 30, LineHeight: 1.5, Grid: 4},
+			// 30sp renders at 38px, not 60px: 57px raw snaps to 56px, which is
+			// 30 + 18*70/62 sp on the 2x table.
+			LineHeight{Sp: 30 + 18*70.0/62, Px: 56, GridPx: 4, PaddingTop: 1, PaddingBottom: 3, Error: -1},
+		},
+		{
+			"tiny",
+			NewMetric(1, 1, 160),
+			Baseline{FontSize: 1, Grid: 8},
+			LineHeight{Sp: 8, Px: 8, GridPx: 8, PaddingTop: 4, PaddingBottom: 4, Error: 7},
+		},
+		{
+			"zero grid",
+			NewMetric(1, 1, 160),
+			Baseline{FontSize: 10, LineHeight: 1.33},
+			LineHeight{Sp: 13, Px: 13, GridPx: 1, PaddingTop: 0, PaddingBottom: 0, Error: -0.3},
+		},
+	}
+
+	for _, test := range tests {
+		res := test.m.SnapLineHeight(test.b)
+		if math.Abs(float64(res.Error-test.expected.Error)) > 1e-4 {
+			t.Errorf("%s: Error = %v; expected %v", test.name, res.Error, test.expected.Error)
+		}
+		if math.Abs(float64(res.Sp-test.expected.Sp)) > 1e-4 {
+			t.Errorf("%s: Sp = %v; expected %v", test.name, res.Sp, test.expected.Sp)
+		}
+		res.Error, res.Sp = test.expected.Error, test.expected.Sp
+		if res != test.expected {
+			t.Errorf("%s: SnapLineHeight = %+v; expected %+v", test.name, res, test.expected)
+		}
+	}
+}
+
+// TestSnapLineHeights checks snapping across density buckets and the largest
+// error among them.
+func TestSnapLineHeights(t *testing.T) {
+	b := Baseline{FontSize: 14, LineHeight: 1.5, Grid: 4}
+	lines := SnapLineHeights(b,
+		NewMetric(1, 1, 160),
+		NewMetric(1.5, 1.5, 240),
+		NewMetric(2, 2, 320),
+	)
+	if len(lines) != 3 {
+		t.Fatalf("len(SnapLineHeights) = %d; expected 3", len(lines))
+	}
+	for i, expected := range []int{20, 30, 40} {
+		if lines[i].Px != expected {
+			t.Errorf("lines[%d].Px = %d; expected %d", i, lines[i].Px, expected)
+		}
+	}
+	if res := MaxLineHeightError(lines); res != 2 {
+		t.Errorf("MaxLineHeightError = %v; expected 2", res)
+	}
+}
+
+// TestPropSnapLineHeight checks the grid invariants for arbitrary inputs.
+func TestPropSnapLineHeight(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := NewMetric(genPositiveFloat32(t, "pxPerDp")/100, genPositiveFloat32(t, "pxPerSp")/100, 160)
+		b := Baseline{
+			FontSize:   Sp(rapid.Float32Range(1, 96).Draw(t, "font")),
+			LineHeight: rapid.Float32Range(0.8, 3).Draw(t, "ratio"),
+			Grid:       Dp(rapid.Float32Range(1, 16).Draw(t, "grid")),
+		}
+		l := m.SnapLineHeight(b)
+		if l.GridPx < 1 || l.Px < l.GridPx || l.Px%l.GridPx != 0 {
+			t.Fatalf("line height %d is not a multiple of grid %d", l.Px, l.GridPx)
+		}
+		if l.PaddingTop < 0 || l.PaddingTop >= l.GridPx || (l.PaddingTop+l.PaddingBottom)%l.GridPx != 0 {
+			t.Fatalf("bad padding %+v", l)
+		}
+		// A single step may be more than half a step above a tiny line height.
+		if l.Px > l.GridPx && math.Abs(float64(l.Error)) > float64(l.GridPx)/2+1e-3 {
+			t.Fatalf("error %v exceeds half a grid step %d", l.Error, l.GridPx)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package pxconv
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 // BenchmarkDpToPx benchmarks the DpToPx method.
 func BenchmarkDpToPx(b *testing.B) {
@@ -146,3 +149,142 @@ func BenchmarkPxToPtParallel(b *testing.B) {
 		}
 	})
 }
+
+// benchCoords returns n dp coordinates for the slice benchmarks.
+func benchCoords(n int) []Dp {
+	src := make([]Dp, n)
+	for i := range src {
+		src[i] = Dp(i) * 0.75
+	}
+	return src
+}
+
+// BenchmarkDpToPxScalarLoop benchmarks converting 10k coordinates with DpToPx in a loop.
+func BenchmarkDpToPxScalarLoop(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(10_000)
+	dst := make([]int, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, v := range src {
+			dst[j] = metric.DpToPx(v)
+		}
+	}
+}
+
+// BenchmarkDpToPxSlice benchmarks converting 10k coordinates with DpToPxSlice.
+func BenchmarkDpToPxSlice(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(10_000)
+	dst := make([]int, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		metric.DpToPxSlice(dst, src)
+	}
+}
+
+// BenchmarkToPxSlice benchmarks converting 10k coordinates with the generic ToPxSlice.
+func BenchmarkToPxSlice(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(10_000)
+	dst := make([]int, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToPxSlice(metric, dst, src)
+	}
+}
+
+// BenchmarkPxToDpScalarLoop benchmarks converting 10k pixel values with PxToDp in a loop.
+func BenchmarkPxToDpScalarLoop(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := make([]int, 10_000)
+	for i := range src {
+		src[i] = i
+	}
+	dst := make([]Dp, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, v := range src {
+			dst[j] = metric.PxToDp(v)
+		}
+	}
+}
+
+// BenchmarkPxToDpSlice benchmarks converting 10k pixel values with PxToDpSlice.
+func BenchmarkPxToDpSlice(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := make([]int, 10_000)
+	for i := range src {
+		src[i] = i
+	}
+	dst := make([]Dp, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		metric.PxToDpSlice(dst, src)
+	}
+}
+
+// benchSink keeps the results of the Converter benchmarks alive so the
+// conversions are not optimized away.
+var benchSink int
+
+// BenchmarkMetricDpToPx benchmarks Metric.DpToPx with the inputs of
+// BenchmarkConverterDpToPx, as a baseline for it.
+func BenchmarkMetricDpToPx(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchSink += metric.DpToPx(src[i&1023])
+	}
+}
+
+// BenchmarkConverterDpToPx benchmarks the DpToPx method of a Converter.
+func BenchmarkConverterDpToPx(b *testing.B) {
+	converter := NewConverter(NewMetric(2.625, 2.625, 420))
+	src := benchCoords(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchSink += converter.DpToPx(src[i&1023])
+	}
+}
+
+// BenchmarkConverterDpToPxTable benchmarks DpToPx of a Converter with a lookup table.
+func BenchmarkConverterDpToPxTable(b *testing.B) {
+	converter := NewConverterWithTable(NewMetric(2.625, 2.625, 420), 256)
+	for i := 0; i < b.N; i++ {
+		benchSink += converter.DpToPx(Dp(i & 127))
+	}
+}
+
+// BenchmarkMetricMmToPx benchmarks Metric.MmToPx with the inputs of
+// BenchmarkConverterMmToPx, as a baseline for it.
+func BenchmarkMetricMmToPx(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchSink += metric.MmToPx(Mm(src[i&1023]))
+	}
+}
+
+// BenchmarkConverterMmToPx benchmarks the MmToPx method of a Converter.
+func BenchmarkConverterMmToPx(b *testing.B) {
+	converter := NewConverter(NewMetric(2.625, 2.625, 420))
+	src := benchCoords(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchSink += converter.MmToPx(Mm(src[i&1023]))
+	}
+}
+
+// BenchmarkBulkDpToPx benchmarks converting 1M coordinates with BulkDpToPx.
+func BenchmarkBulkDpToPx(b *testing.B) {
+	metric := NewMetric(2.625, 2.625, 420)
+	src := benchCoords(1_000_000)
+	dst := make([]int, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = metric.BulkDpToPx(context.Background(), dst, src, BulkOptions{})
+	}
+}
@@ -0,0 +1,132 @@
+package pxconv
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBulkChunkSize is the chunk size used by the bulk conversions when
+// BulkOptions.ChunkSize is not set.
+const DefaultBulkChunkSize = 16 * 1024
+
+// BulkOptions configures the parallel bulk conversions.
+type BulkOptions struct {
+	// Workers is the number of goroutines. Zero or less means runtime.GOMAXPROCS(0).
+	Workers int
+	// ChunkSize is the number of elements a worker converts between checks for
+	// cancellation. Zero or less means DefaultBulkChunkSize.
+	ChunkSize int
+	// Progress, if set, is called after every chunk with the number of elements
+	// converted so far and the total. Calls are serialized and done never decreases.
+	Progress func(done, total int)
+}
+
+// The bulk methods below split src into chunks and convert them with several
+// goroutines, writing results into dst. They panic if dst is shorter than src.
+// If ctx is canceled, they stop starting new chunks and return ctx.Err(); dst is
+// then only partially written.
+
+// BulkDpToPx converts dp values to pixels in parallel, like DpToPxSlice.
+func (c Metric) BulkDpToPx(ctx context.Context, dst []int, src []Dp, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.DpToPxSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkSpToPx converts sp values to pixels in parallel, like SpToPxSlice.
+func (c Metric) BulkSpToPx(ctx context.Context, dst []int, src []Sp, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.SpToPxSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkInchToPx converts inches to pixels in parallel, like InchToPxSlice.
+func (c Metric) BulkInchToPx(ctx context.Context, dst []int, src []Inch, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.InchToPxSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkMmToPx converts millimeters to pixels in parallel, like MmToPxSlice.
+func (c Metric) BulkMmToPx(ctx context.Context, dst []int, src []Mm, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.MmToPxSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkPtToPx converts points to pixels in parallel, like PtToPxSlice.
+func (c Metric) BulkPtToPx(ctx context.Context, dst []int, src []Pt, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.PtToPxSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkPxToDp converts pixels to dp in parallel, like PxToDpSlice.
+func (c Metric) BulkPxToDp(ctx context.Context, dst []Dp, src []int, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.PxToDpSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkPxToSp converts pixels to sp in parallel, like PxToSpSlice.
+func (c Metric) BulkPxToSp(ctx context.Context, dst []Sp, src []int, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { c.PxToSpSlice(dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkToPx converts values of any unit to pixels in parallel, like ToPxSlice.
+func BulkToPx[F Unit](ctx context.Context, m Metric, dst []int, src []F, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { ToPxSlice(m, dst[lo:hi], src[lo:hi]) })
+}
+
+// BulkFromPx converts pixels to any unit in parallel, like FromPxSlice.
+func BulkFromPx[T Unit](ctx context.Context, m Metric, dst []T, src []int, opts BulkOptions) error {
+	dst = dst[:len(src)]
+	return bulk(ctx, len(src), opts, func(lo, hi int) { FromPxSlice(m, dst[lo:hi], src[lo:hi]) })
+}
+
+// bulk runs convert over [0, n) in chunks on a pool of goroutines.
+func bulk(ctx context.Context, n int, opts BulkOptions, convert func(lo, hi int)) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunk := opts.ChunkSize
+	if chunk <= 0 {
+		chunk = DefaultBulkChunkSize
+	}
+	chunks := (n + chunk - 1) / chunk
+	workers = min(workers, chunks)
+
+	var (
+		next atomic.Int64
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				k := int(next.Add(1)) - 1
+				if k >= chunks {
+					return
+				}
+				lo := k * chunk
+				hi := min(lo+chunk, n)
+				convert(lo, hi)
+
+				mu.Lock()
+				done += hi - lo
+				if opts.Progress != nil {
+					opts.Progress(done, n)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if done == n {
+		return nil
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,91 @@
+package pxconv
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestBulkMatchesSlice checks that bulk conversions match the slice methods.
+func TestBulkMatchesSlice(t *testing.T) {
+	m := NewMetric(2.625, 1.75, 420)
+	src := benchCoords(100_003)
+	want := make([]int, len(src))
+	m.DpToPxSlice(want, src)
+
+	for _, opts := range []BulkOptions{{}, {Workers: 1}, {Workers: 8, ChunkSize: 1000}, {Workers: 64, ChunkSize: 7}} {
+		got := make([]int, len(src))
+		if err := m.BulkDpToPx(context.Background(), got, src, opts); err != nil {
+			t.Fatalf("BulkDpToPx(%+v) returned %v", opts, err)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("BulkDpToPx(%+v)[%d] = %v; expected %v", opts, i, got[i], want[i])
+			}
+		}
+	}
+
+	back := make([]Dp, len(want))
+	if err := BulkFromPx(context.Background(), m, back, want, BulkOptions{ChunkSize: 500}); err != nil {
+		t.Fatalf("BulkFromPx returned %v", err)
+	}
+	for i, px := range want {
+		if back[i] != m.PxToDp(px) {
+			t.Fatalf("BulkFromPx[%d] = %v; expected %v", i, back[i], m.PxToDp(px))
+		}
+	}
+}
+
+// TestBulkProgress checks that progress is reported monotonically up to the total.
+func TestBulkProgress(t *testing.T) {
+	m := NewMetric(2, 2, 96)
+	src := make([]Pt, 10_000)
+	dst := make([]int, len(src))
+
+	last, calls := 0, 0
+	opts := BulkOptions{
+		Workers:   4,
+		ChunkSize: 100,
+		Progress: func(done, total int) {
+			if done <= last || total != len(src) {
+				t.Errorf("Progress(%v, %v) after %v", done, total, last)
+			}
+			last = done
+			calls++
+		},
+	}
+	if err := BulkToPx(context.Background(), m, dst, src, opts); err != nil {
+		t.Fatalf("BulkToPx returned %v", err)
+	}
+	if last != len(src) || calls != 100 {
+		t.Errorf("final progress = %v after %v calls; expected %v after 100", last, calls, len(src))
+	}
+}
+
+// TestBulkCancel checks that a canceled context stops the conversion.
+func TestBulkCancel(t *testing.T) {
+	m := NewMetric(2, 2, 96)
+	src := make([]int, 100_000)
+	dst := make([]Sp, len(src))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := BulkOptions{
+		Workers:   2,
+		ChunkSize: 10,
+		Progress: func(done, _ int) {
+			if done >= 1000 {
+				cancel()
+			}
+		},
+	}
+	if err := m.BulkPxToSp(ctx, dst, src, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("BulkPxToSp after cancel returned %v; expected context.Canceled", err)
+	}
+
+	if err := m.BulkPxToSp(ctx, dst, src, BulkOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("BulkPxToSp with canceled context returned %v; expected context.Canceled", err)
+	}
+	if err := m.BulkPxToSp(ctx, nil, nil, BulkOptions{}); err != nil {
+		t.Errorf("BulkPxToSp with empty input returned %v; expected nil", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Package calibrate corrects a wrong reported DPI from ruler measurements.
+//
+// The user measures lines drawn on screen with a known pixel length. New
+// estimates the DPI of each axis by least squares, with a standard error, and
+// Calibration.Apply returns a Metric with the corrected Dpi. Calibrations can be
+// saved to and loaded from a small JSON file.
+package calibrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv"
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+var (
+	// ErrNoSamples is returned when there is nothing to fit.
+	ErrNoSamples = errors.New("calibrate: no samples")
+	// ErrInvalidSample is returned for a sample that cannot be used.
+	ErrInvalidSample = errors.New("calibrate: invalid sample")
+	// ErrFormat is returned for a calibration file that cannot be read.
+	ErrFormat = errors.New("calibrate: invalid file")
+)
+
+// FormatVersion is the version written to calibration files.
+const FormatVersion = 1
+
+// Axis is the direction of a measured line.
+type Axis uint8
+
+const (
+	// Horizontal is a line along the x axis.
+	Horizontal Axis = iota
+	// Vertical is a line along the y axis.
+	Vertical
+)
+
+// String returns "horizontal" or "vertical".
+func (a Axis) String() string {
+	switch a {
+	case Vertical:
+		return "vertical"
+	default:
+		return "horizontal"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Axis) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Axis) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "horizontal":
+		*a = Horizontal
+	case "vertical":
+		*a = Vertical
+	default:
+		return fmt.Errorf("%w: axis %q", ErrFormat, text)
+	}
+	return nil
+}
+
+// Sample is one ruler measurement of a line drawn on screen.
+type Sample struct {
+	// Axis is the direction of the line.
+	Axis Axis
+	// Px is the rendered length in device pixels.
+	Px int
+	// Measured is the length read from the ruler, in mm, in or pt.
+	Measured pxconv.Length
+}
+
+// Fit is the DPI estimated from the samples of one axis, or of both.
+type Fit struct {
+	// Dpi is the least squares estimate of dots per inch.
+	Dpi float32 `json:"dpi"`
+	// StdErr is the standard error of Dpi. It is zero with a single sample,
+	// since one measurement says nothing about its own error.
+	StdErr float32 `json:"stderr"`
+	// Samples is the number of samples used.
+	Samples int `json:"samples"`
+}
+
+// Calibration is the result of fitting a set of samples.
+type Calibration struct {
+	// Display optionally identifies the calibrated display.
+	Display string
+	// Horizontal and Vertical are the per-axis fits. An axis without samples
+	// has a zero Fit.
+	Horizontal, Vertical Fit
+	// Combined is the fit of all samples, used by Apply.
+	Combined Fit
+	// Samples are the measurements the fits were computed from.
+	Samples []Sample
+}
+
+// New fits the samples and returns the calibration. Samples must have a
+// positive pixel length and a positive length in mm, in or pt.
+//
+// Each fit is a line through the origin, px = dpi * inches, so a ruler that
+// is not aligned with the start of the line only adds noise. The standard
+// error comes from the residuals of that line.
+func New(samples []Sample) (Calibration, error) {
+	if len(samples) == 0 {
+		return Calibration{}, ErrNoSamples
+	}
+	inches := make([]float64, len(samples))
+	for i, s := range samples {
+		in, err := sampleInches(s)
+		if err != nil {
+			return Calibration{}, fmt.Errorf("sample %d: %w", i, err)
+		}
+		inches[i] = in
+	}
+	return Calibration{
+		Horizontal: fit(samples, inches, func(s Sample) bool { return s.Axis == Horizontal }),
+		Vertical:   fit(samples, inches, func(s Sample) bool { return s.Axis == Vertical }),
+		Combined:   fit(samples, inches, func(Sample) bool { return true }),
+		Samples:    append([]Sample(nil), samples...),
+	}, nil
+}
+
+// Apply returns m with Dpi replaced by the combined fit. PxPerDp and PxPerSp
+// are logical scales chosen by the platform and are left unchanged.
+func (c Calibration) Apply(m pxconv.Metric) pxconv.Metric {
+	if c.Combined.Dpi > 0 {
+		m.Dpi = c.Combined.Dpi
+	}
+	return m
+}
+
+// Correction returns the ratio of the calibrated DPI to the reported one,
+// such as 1.08 for a screen whose real DPI is 8% higher than reported.
+func (c Calibration) Correction(reported float32) float32 {
+	if reported <= 0 || c.Combined.Dpi <= 0 {
+		return 1
+	}
+	return c.Combined.Dpi / reported
+}
+
+// sampleInches validates a sample and returns its measured length in inches.
+func sampleInches(s Sample) (float64, error) {
+	if s.Px <= 0 {
+		return 0, fmt.Errorf("%w: pixel length %d", ErrInvalidSample, s.Px)
+	}
+	if s.Axis != Horizontal && s.Axis != Vertical {
+		return 0, fmt.Errorf("%w: axis %d", ErrInvalidSample, s.Axis)
+	}
+	v := float64(s.Measured.Value)
+	if !(v > 0) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%w: measured length %v", ErrInvalidSample, s.Measured)
+	}
+	switch s.Measured.Unit {
+	case pxconv.UnitInch:
+		return v, nil
+	case pxconv.UnitMm:
+		return v / consts.MmPerInch, nil
+	case pxconv.UnitPt:
+		return v / consts.PointsPerInch, nil
+	default:
+		return 0, fmt.Errorf("%w: %v is not a physical length", ErrInvalidSample, s.Measured)
+	}
+}
+
+// fit fits px = dpi * inches through the origin over the samples that match.
+func fit(samples []Sample, inches []float64, match func(Sample) bool) Fit {
+	var sxy, sxx float64
+	n := 0
+	for i, s := range samples {
+		if !match(s) {
+			continue
+		}
+		sxy += float64(s.Px) * inches[i]
+		sxx += inches[i] * inches[i]
+		n++
+	}
+	if n == 0 {
+		return Fit{}
+	}
+	dpi := sxy / sxx
+	f := Fit{Dpi: float32(dpi), Samples: n}
+	if n > 1 {
+		var ss float64
+		for i, s := range samples {
+			if match(s) {
+				r := float64(s.Px) - dpi*inches[i]
+				ss += r * r
+			}
+		}
+		f.StdErr = float32(math.Sqrt(ss / float64(n-1) / sxx))
+	}
+	return f
+}
+
+// file is the JSON layout of a calibration file.
+type file struct {
+	Version    int          `json:"version"`
+	Display    string       `json:"display,omitempty"`
+	Dpi        float32      `json:"dpi"`
+	Horizontal *Fit         `json:"horizontal,omitempty"`
+	Vertical   *Fit         `json:"vertical,omitempty"`
+	Samples    []fileSample `json:"samples"`
+}
+
+type fileSample struct {
+	Axis     Axis   `json:"axis"`
+	Px       int    `json:"px"`
+	Measured string `json:"measured"`
+}
+
+// Save writes the calibration as JSON:
+//
+//	{
+//	  "version": 1,
+//	  "display": "DELL U2720Q",
+//	  "dpi": 163.2,
+//	  "horizontal": {"dpi": 163.1, "stderr": 0.2, "samples": 2},
+//	  "vertical": {"dpi": 163.4, "stderr": 0, "samples": 1},
+//	  "samples": [{"axis": "horizontal", "px": 1000, "measured": "155.7mm"}]
+//	}
+//
+// The samples are the source of truth; the fits are stored for readers in
+// other languages and recomputed by Load.
+func Save(w io.Writer, c Calibration) error {
+	f := file{
+		Version: FormatVersion,
+		Display: c.Display,
+		Dpi:     c.Combined.Dpi,
+		Samples: make([]fileSample, len(c.Samples)),
+	}
+	if c.Horizontal.Samples > 0 {
+		f.Horizontal = &c.Horizontal
+	}
+	if c.Vertical.Samples > 0 {
+		f.Vertical = &c.Vertical
+	}
+	for i, s := range c.Samples {
+		f.Samples[i] = fileSample{Axis: s.Axis, Px: s.Px, Measured: s.Measured.String()}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// Load reads a calibration written by Save and refits its samples.
+func Load(r io.Reader) (Calibration, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return Calibration{}, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if f.Version != FormatVersion {
+		return Calibration{}, fmt.Errorf("%w: unsupported version %d", ErrFormat, f.Version)
+	}
+	samples := make([]Sample, len(f.Samples))
+	for i, s := range f.Samples {
+		l, err := pxconv.ParseLength(s.Measured)
+		if err != nil {
+			return Calibration{}, fmt.Errorf("%w: sample %d: %w", ErrFormat, i, err)
+		}
+		samples[i] = Sample{Axis: s.Axis, Px: s.Px, Measured: l}
+	}
+	c, err := New(samples)
+	if err != nil {
+		return Calibration{}, err
+	}
+	c.Display = f.Display
+	return c, nil
+}
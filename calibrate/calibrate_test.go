@@ -0,0 +1,145 @@
+package calibrate
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// mm builds a length in millimeters for the test samples.
+func mm(v float32) pxconv.Length { return pxconv.Length{Value: v, Unit: pxconv.UnitMm} }
+
+// TestNewExact checks that exact samples in mm, in and pt fit 96 dpi with no
+// error on each axis.
+func TestNewExact(t *testing.T) {
+	// 96 dpi: 96px is one inch on both axes.
+	c, err := New([]Sample{
+		{Axis: Horizontal, Px: 96, Measured: mm(25.4)},
+		{Axis: Horizontal, Px: 192, Measured: pxconv.Length{Value: 2, Unit: pxconv.UnitInch}},
+		{Axis: Vertical, Px: 48, Measured: pxconv.Length{Value: 36, Unit: pxconv.UnitPt}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, f := range map[string]Fit{"horizontal": c.Horizontal, "vertical": c.Vertical, "combined": c.Combined} {
+		if math.Abs(float64(f.Dpi)-96) > 1e-4 || f.StdErr > 1e-3 {
+			t.Errorf("%s = %+v; expected 96 dpi with no error", name, f)
+		}
+	}
+	if c.Horizontal.Samples != 2 || c.Vertical.Samples != 1 || c.Combined.Samples != 3 {
+		t.Errorf("sample counts = %d, %d, %d; expected 2, 1, 3", c.Horizontal.Samples, c.Vertical.Samples, c.Combined.Samples)
+	}
+}
+
+// TestNewNoisy checks a least-squares fit of noisy samples and applying it to
+// a Metric.
+func TestNewNoisy(t *testing.T) {
+	// A 27" 4K display is about 163 dpi but reports 96.
+	c, err := New([]Sample{
+		{Axis: Horizontal, Px: 1000, Measured: mm(155.5)},
+		{Axis: Horizontal, Px: 2000, Measured: mm(311.5)},
+		{Axis: Horizontal, Px: 500, Measured: mm(78)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := c.Horizontal
+	if math.Abs(float64(h.Dpi)-163.1) > 0.1 {
+		t.Errorf("Dpi = %v; expected about 163.1", h.Dpi)
+	}
+	if h.StdErr <= 0 || h.StdErr > 0.5 {
+		t.Errorf("StdErr = %v; expected a small positive error", h.StdErr)
+	}
+	if c.Vertical != (Fit{}) {
+		t.Errorf("Vertical = %+v; expected zero", c.Vertical)
+	}
+
+	m := c.Apply(pxconv.NewMetric(2, 2, 96))
+	if m.Dpi != c.Combined.Dpi || m.PxPerDp != 2 {
+		t.Errorf("Apply = %+v; expected Dpi %v and PxPerDp 2", m, c.Combined.Dpi)
+	}
+	if res := m.MmToPx(100); math.Abs(float64(res)-642) > 1 {
+		t.Errorf("MmToPx(100) after calibration = %d; expected about 642", res)
+	}
+	if res := c.Correction(96); math.Abs(float64(res)-1.699) > 0.01 {
+		t.Errorf("Correction(96) = %v; expected about 1.699", res)
+	}
+}
+
+// TestNewErrors checks that empty input and invalid samples are rejected.
+func TestNewErrors(t *testing.T) {
+	if _, err := New(nil); !errors.Is(err, ErrNoSamples) {
+		t.Errorf("New(nil) error = %v; expected ErrNoSamples", err)
+	}
+	tests := []Sample{
+		{Px: 0, Measured: mm(10)},
+		{Px: 100, Measured: mm(0)},
+		{Px: 100, Measured: mm(float32(math.NaN()))},
+		{Px: 100, Measured: pxconv.Length{Value: 10, Unit: pxconv.UnitDp}},
+		{Axis: 7, Px: 100, Measured: mm(10)},
+	}
+
+	for _, test := range tests {
+		if _, err := New([]Sample{test}); !errors.Is(err, ErrInvalidSample) {
+			t.Errorf("New(%+v) error = %v; expected ErrInvalidSample", test, err)
+		}
+	}
+}
+
+// TestSaveLoad checks the saved JSON and that Load restores the calibration.
+func TestSaveLoad(t *testing.T) {
+	c, err := New([]Sample{
+		{Axis: Horizontal, Px: 1000, Measured: mm(155.5)},
+		{Axis: Vertical, Px: 1000, Measured: pxconv.Length{Value: 6.1, Unit: pxconv.UnitInch}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Display = "DELL U2720Q"
+
+	var b bytes.Buffer
+	if err := Save(&b, c); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`"version": 1`, `"display": "DELL U2720Q"`, `"axis": "vertical"`, `"measured": "6.1in"`} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("file does not contain %s:\n%s", expected, b.String())
+		}
+	}
+
+	res, err := Load(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, c) {
+		t.Errorf("Load = %+v; expected %+v", res, c)
+	}
+}
+
+// TestLoadErrors checks that malformed files are rejected with the matching
+// error.
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected error
+	}{
+		{"json", `{`, ErrFormat},
+		{"version", `{"version": 2, "samples": []}`, ErrFormat},
+		{"axis", `{"version": 1, "samples": [{"axis": "diagonal", "px": 10, "measured": "1mm"}]}`, ErrFormat},
+		{"length", `{"version": 1, "samples": [{"axis": "vertical", "px": 10, "measured": "1 furlong"}]}`, ErrFormat},
+		{"empty", `{"version": 1, "samples": []}`, ErrNoSamples},
+		{"sample", `{"version": 1, "samples": [{"axis": "vertical", "px": 10, "measured": "1dp"}]}`, ErrInvalidSample},
+	}
+
+	for _, test := range tests {
+		if _, err := Load(strings.NewReader(test.src)); !errors.Is(err, test.expected) {
+			t.Errorf("%s: Load error = %v; expected %v", test.name, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package pxconv
+
+import "context"
+
+// metricKey is the context key for a Metric.
+type metricKey struct{}
+
+// WithMetric returns a copy of ctx that carries m, for example the density of
+// the client that made the current request.
+func WithMetric(ctx context.Context, m Metric) context.Context {
+	return context.WithValue(ctx, metricKey{}, m)
+}
+
+// MetricFrom returns the Metric stored in ctx by WithMetric, or Default()
+// if ctx carries none.
+func MetricFrom(ctx context.Context) Metric {
+	if m, ok := ctx.Value(metricKey{}).(Metric); ok {
+		return m
+	}
+	return Default()
+}
+
+// ToContext converts value to the unit T using the Metric from ctx.
+func ToContext[T, F Unit](ctx context.Context, value F) T {
+	return To[T](MetricFrom(ctx), value)
+}
+
+// ToPxContext converts value to pixels using the Metric from ctx,
+// rounding to the nearest integer.
+func ToPxContext[F Unit](ctx context.Context, value F) int {
+	return ToPx(MetricFrom(ctx), value)
+}
+
+// FromPxContext converts a pixel value to the unit T using the Metric from ctx.
+func FromPxContext[T Unit](ctx context.Context, value int) T {
+	return FromPx[T](MetricFrom(ctx), value)
+}
@@ -0,0 +1,39 @@
+package pxconv
+
+import (
+	"context"
+	"testing"
+)
+
+// TestMetricFromContext checks that a Metric stored in a context is returned.
+func TestMetricFromContext(t *testing.T) {
+	m := NewMetric(3, 3.5, 480)
+	ctx := WithMetric(context.Background(), m)
+
+	if res := MetricFrom(ctx); res != m {
+		t.Errorf("MetricFrom(ctx) = %+v; expected %+v", res, m)
+	}
+	if res := ToPxContext(ctx, Dp(10)); res != 30 {
+		t.Errorf("ToPxContext(Dp(10)) = %v; expected 30", res)
+	}
+	if res := ToContext[Sp](ctx, Dp(7)); res != 6 {
+		t.Errorf("ToContext[Sp](Dp(7)) = %v; expected 6", res)
+	}
+	if res := FromPxContext[Inch](ctx, 960); res != 2 {
+		t.Errorf("FromPxContext[Inch](960) = %v; expected 2", res)
+	}
+}
+
+// TestMetricFromContextFallback checks the fallback to the default Metric.
+func TestMetricFromContextFallback(t *testing.T) {
+	restoreDefault(t)
+	m := NewMetric(2, 2, 192)
+	SetDefault(m)
+
+	if res := MetricFrom(context.Background()); res != m {
+		t.Errorf("MetricFrom(empty ctx) = %+v; expected default %+v", res, m)
+	}
+	if res := ToPxContext(context.Background(), Dp(10)); res != 20 {
+		t.Errorf("ToPxContext(Dp(10)) = %v; expected 20", res)
+	}
+}
@@ -0,0 +1,160 @@
+package pxconv
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+// Converter is a Metric prepared for the hot path. Densities are validated and
+// every factor is computed once in NewConverter, so DpToPx, SpToPx and InchToPx
+// cost a single multiply and round per call.
+//
+// Results are bit-identical to the Metric methods. Where a Metric method
+// multiplies and then divides (for example MmToPx or DpToSp), the Converter keeps
+// both operations, since folding them into one factor would change the last bit.
+//
+// A Converter is immutable and safe for concurrent use.
+type Converter struct {
+	metric Metric
+
+	pxPerDp, pxPerSp     float64
+	pxPerDp32, pxPerSp32 float32
+	dpi, pointsPerInch   float64
+	pointsPerInch32      float32
+
+	dpTable, spTable []int
+
+	fonts     fontTable
+	nonlinear bool
+}
+
+// NewConverter creates a Converter for the given Metric.
+func NewConverter(m Metric) Converter {
+	pxPerDp, pxPerSp := density.EnsurePositive(m.PxPerDp), density.EnsurePositive(m.PxPerSp)
+	perInch := m.Points.perInch()
+	fonts, nonlinear := m.fontTable()
+	return Converter{
+		metric:          m,
+		pxPerDp:         float64(pxPerDp),
+		pxPerSp:         float64(pxPerSp),
+		pxPerDp32:       pxPerDp,
+		pxPerSp32:       pxPerSp,
+		dpi:             float64(m.Dpi),
+		pointsPerInch:   perInch,
+		pointsPerInch32: float32(perInch),
+		fonts:           fonts,
+		nonlinear:       nonlinear,
+	}
+}
+
+// NewConverterWithTable creates a Converter that also keeps lookup tables for
+// the whole dp and sp values 0 through size-1, which DpToPx and SpToPx consult
+// before computing. A size of zero or less disables the tables.
+func NewConverterWithTable(m Metric, size int) Converter {
+	c := NewConverter(m)
+	if size <= 0 {
+		return c
+	}
+	base := c
+	c.dpTable, c.spTable = make([]int, size), make([]int, size)
+	for i := 0; i < size; i++ {
+		c.dpTable[i] = base.DpToPx(Dp(i))
+		c.spTable[i] = base.SpToPx(Sp(i))
+	}
+	return c
+}
+
+// Metric returns the Metric the Converter was built from.
+func (c Converter) Metric() Metric {
+	return c.metric
+}
+
+// DpToPx converts a dp value to pixels, rounding to the nearest integer.
+func (c Converter) DpToPx(value Dp) int {
+	if i := int(value); Dp(i) == value && uint(i) < uint(len(c.dpTable)) {
+		return c.dpTable[i]
+	}
+	return roundToInt(c.pxPerDp * float64(value))
+}
+
+// SpToPx converts an sp value to pixels, rounding to the nearest integer.
+func (c Converter) SpToPx(value Sp) int {
+	if i := int(value); Sp(i) == value && uint(i) < uint(len(c.spTable)) {
+		return c.spTable[i]
+	}
+	if c.nonlinear {
+		return roundToInt(c.pxPerDp * float64(c.fonts.spToDp(value)))
+	}
+	return roundToInt(c.pxPerSp * float64(value))
+}
+
+// DpToSp converts a dp value to sp.
+func (c Converter) DpToSp(value Dp) Sp {
+	if c.nonlinear {
+		return c.fonts.dpToSp(value)
+	}
+	return Sp(float32(value) * c.pxPerDp32 / c.pxPerSp32)
+}
+
+// SpToDp converts an sp value to dp.
+func (c Converter) SpToDp(value Sp) Dp {
+	if c.nonlinear {
+		return c.fonts.spToDp(value)
+	}
+	return Dp(float32(value) * c.pxPerSp32 / c.pxPerDp32)
+}
+
+// PxToDp converts a pixel value to dp.
+func (c Converter) PxToDp(value int) Dp {
+	return Dp(float32(value) / c.pxPerDp32)
+}
+
+// PxToSp converts a pixel value to sp.
+func (c Converter) PxToSp(value int) Sp {
+	if c.nonlinear {
+		return c.fonts.dpToSp(c.PxToDp(value))
+	}
+	return Sp(float32(value) / c.pxPerSp32)
+}
+
+// InchToPx converts inches to pixels using the DPI.
+func (c Converter) InchToPx(value Inch) int {
+	return roundToInt(float64(value) * c.dpi)
+}
+
+// MmToPx converts millimeters to pixels using the DPI.
+func (c Converter) MmToPx(value Mm) int {
+	return roundToInt(float64(value) * c.dpi / consts.MmPerInch)
+}
+
+// PtToPx converts points to pixels using the DPI and point system.
+func (c Converter) PtToPx(value Pt) int {
+	return roundToInt(float64(value) * c.dpi / c.pointsPerInch)
+}
+
+// EmuToPx converts EMU to pixels using the DPI.
+func (c Converter) EmuToPx(value Emu) int {
+	return roundToInt(float64(value) * c.dpi / consts.EmuPerInch)
+}
+
+// PxToInch converts pixels to inches using the DPI.
+func (c Converter) PxToInch(value int) Inch {
+	return Inch(float32(value) / c.metric.Dpi)
+}
+
+// PxToMm converts pixels to millimeters using the DPI.
+func (c Converter) PxToMm(value int) Mm {
+	return Mm(float32(value) * consts.MmPerInch / c.metric.Dpi)
+}
+
+// PxToPt converts pixels to points using the DPI and point system.
+func (c Converter) PxToPt(value int) Pt {
+	return Pt(float32(value) * c.pointsPerInch32 / c.metric.Dpi)
+}
+
+// PxToEmu converts pixels to EMU using the DPI, rounding to the nearest integer.
+func (c Converter) PxToEmu(value int) Emu {
+	return Emu(math.Round(float64(value) * consts.EmuPerInch / c.dpi))
+}
@@ -0,0 +1,57 @@
+package pxconv
+
+import (
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestConverterMatchesMetric checks that Converter results are bit-identical to Metric methods.
+func TestConverterMatchesMetric(t *testing.T) {
+	metrics := []Metric{
+		NewMetric(2, 1.5, 96),
+		NewMetric(2.625, 3.1, 420).WithPoints(PointsTeX),
+		{PxPerDp: 0, PxPerSp: -1, Dpi: 72},
+	}
+
+	for _, m := range metrics {
+		for _, c := range []Converter{NewConverter(m), NewConverterWithTable(m, 256)} {
+			for i := -2000; i < 2000; i++ {
+				v := float32(i) / 4
+				if c.DpToPx(Dp(v)) != m.DpToPx(Dp(v)) || c.SpToPx(Sp(v)) != m.SpToPx(Sp(v)) ||
+					c.DpToSp(Dp(v)) != m.DpToSp(Dp(v)) || c.SpToDp(Sp(v)) != m.SpToDp(Sp(v)) ||
+					c.InchToPx(Inch(v)) != m.InchToPx(Inch(v)) || c.MmToPx(Mm(v)) != m.MmToPx(Mm(v)) ||
+					c.PtToPx(Pt(v)) != m.PtToPx(Pt(v)) || c.EmuToPx(Emu(i*635)) != m.EmuToPx(Emu(i*635)) {
+					t.Fatalf("Converter mismatch for %v with metric %+v", v, m)
+				}
+				if c.PxToDp(i) != m.PxToDp(i) || c.PxToSp(i) != m.PxToSp(i) ||
+					c.PxToInch(i) != m.PxToInch(i) || c.PxToMm(i) != m.PxToMm(i) ||
+					c.PxToPt(i) != m.PxToPt(i) || c.PxToEmu(i) != m.PxToEmu(i) {
+					t.Fatalf("Converter mismatch for %vpx with metric %+v", i, m)
+				}
+			}
+		}
+	}
+}
+
+// TestPropConverterMatchesMetric checks bit-identical results for arbitrary densities.
+func TestPropConverterMatchesMetric(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := NewMetric(genPositiveFloat32(t, "pxPerDp"), genPositiveFloat32(t, "pxPerSp"), genPositiveDpi(t))
+		c := NewConverterWithTable(m, 64)
+		v := rapid.Float32Range(-1e5, 1e5).Draw(t, "v")
+
+		if c.DpToPx(Dp(v)) != m.DpToPx(Dp(v)) || c.SpToPx(Sp(v)) != m.SpToPx(Sp(v)) ||
+			c.MmToPx(Mm(v)) != m.MmToPx(Mm(v)) || c.DpToSp(Dp(v)) != m.DpToSp(Dp(v)) {
+			t.Fatalf("Converter mismatch for %v with metric %+v", v, m)
+		}
+	})
+}
+
+// TestConverterMetric checks that the source Metric is preserved.
+func TestConverterMetric(t *testing.T) {
+	m := NewMetric(2, 1.5, 96)
+	if res := NewConverter(m).Metric(); res != m {
+		t.Errorf("Metric() = %+v; expected %+v", res, m)
+	}
+}
@@ -0,0 +1,70 @@
+package pxconv
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+// The process-wide default Metric. It is optional: code that passes Metric
+// values explicitly is not affected by it.
+var (
+	defaultMetric atomic.Pointer[Metric]
+
+	// setMu serializes SetDefault so listeners see changes in order.
+	setMu sync.Mutex
+
+	subsMu    sync.Mutex
+	subs      = map[uint64]func(prev, next Metric){}
+	nextSubID uint64
+)
+
+// Default returns the process-wide default Metric. Until SetDefault is called it
+// returns NewMetric(1, 1, 96). It is safe for concurrent use and never blocks.
+func Default() Metric {
+	if m := defaultMetric.Load(); m != nil {
+		return *m
+	}
+	return NewMetric(1, 1, consts.DefaultDpi)
+}
+
+// SetDefault replaces the process-wide default Metric and synchronously notifies
+// every listener registered with Subscribe. Concurrent calls are serialized, so
+// listeners observe changes in the order they take effect.
+func SetDefault(m Metric) {
+	setMu.Lock()
+	defer setMu.Unlock()
+
+	prev := Default()
+	defaultMetric.Store(&m)
+
+	subsMu.Lock()
+	listeners := make([]func(prev, next Metric), 0, len(subs))
+	for _, fn := range subs {
+		listeners = append(listeners, fn)
+	}
+	subsMu.Unlock()
+
+	for _, fn := range listeners {
+		fn(prev, m)
+	}
+}
+
+// Subscribe registers fn to be called after every SetDefault with the previous
+// and the new default Metric, for example when the user changes the font scale.
+// fn runs on the goroutine that called SetDefault and must not call SetDefault
+// itself. The returned function removes the listener; it is safe to call more than once.
+func Subscribe(fn func(prev, next Metric)) (unsubscribe func()) {
+	subsMu.Lock()
+	id := nextSubID
+	nextSubID++
+	subs[id] = fn
+	subsMu.Unlock()
+
+	return func() {
+		subsMu.Lock()
+		delete(subs, id)
+		subsMu.Unlock()
+	}
+}
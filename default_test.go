@@ -0,0 +1,86 @@
+package pxconv
+
+import (
+	"sync"
+	"testing"
+)
+
+// restoreDefault resets the default Metric after a test.
+func restoreDefault(t *testing.T) {
+	prev := Default()
+	t.Cleanup(func() { SetDefault(prev) })
+}
+
+// TestDefaultInitial checks the initial default Metric.
+func TestDefaultInitial(t *testing.T) {
+	if defaultMetric.Load() != nil {
+		t.Skip("default Metric already set")
+	}
+	if res := Default(); res != NewMetric(1, 1, 96) {
+		t.Errorf("Default() = %+v; expected %+v", res, NewMetric(1, 1, 96))
+	}
+}
+
+// TestSetDefaultNotifies checks that listeners receive changes until they unsubscribe.
+func TestSetDefaultNotifies(t *testing.T) {
+	restoreDefault(t)
+	first, second := NewMetric(2, 2, 160), NewMetric(2, 3, 160)
+	SetDefault(first)
+
+	var got []Metric
+	unsubscribe := Subscribe(func(prev, next Metric) {
+		if prev != first {
+			t.Errorf("listener got prev %+v; expected %+v", prev, first)
+		}
+		got = append(got, next)
+	})
+	SetDefault(second)
+	unsubscribe()
+	unsubscribe()
+	SetDefault(first)
+
+	if len(got) != 1 || got[0] != second {
+		t.Errorf("listener got %+v; expected [%+v]", got, second)
+	}
+	if res := Default(); res != first {
+		t.Errorf("Default() = %+v; expected %+v", res, first)
+	}
+}
+
+// TestDefaultConcurrency checks the default Metric under concurrent use; run with -race.
+func TestDefaultConcurrency(t *testing.T) {
+	restoreDefault(t)
+
+	var mu sync.Mutex
+	count := 0
+	unsubscribe := Subscribe(func(_, _ Metric) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			SetDefault(NewMetric(float32(i+1), 1, 96))
+		}(i)
+		go func() {
+			defer wg.Done()
+			if res := Default().DpToPx(1); res < 1 {
+				t.Errorf("Default().DpToPx(1) = %v", res)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			Subscribe(func(_, _ Metric) {})()
+		}()
+	}
+	wg.Wait()
+
+	if count != 50 {
+		t.Errorf("listener called %v times; expected 50", count)
+	}
+}
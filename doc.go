@@ -26,6 +26,8 @@
 //   - PxPerDp: Number of pixels per Dp.
 //   - PxPerSp: Number of pixels per Sp.
 //   - Dpi: Screen density in dots per inch.
+//   - Points: Which point Pt means, PostScript (1/72 inch, the default) or TeX (1/72.27 inch).
+//   - FontScaling: How sp are scaled, linearly (the default) or with Android 14's nonlinear tables.
 //
 // # Creating a Metric Instance
 //
@@ -71,6 +73,43 @@
 //	spFromPx := metric.PxToSp(15)           // Result: 10 sp
 //	ptFromPx := metric.PxToPt(16)           // Result: 12 pt (at DPI 96)
 //
+// # Generic Conversion
+//
+// Every float32 unit type satisfies the Unit constraint, so typed code can
+// convert between any two units without knowing the method name:
+//
+//	mm := pxconv.To[pxconv.Mm](metric, pxconv.Dp(10))
+//	px := pxconv.ToPx(metric, pxconv.Pt(12))
+//
+// # Default Metric
+//
+// Applications that need "the current display metric" in many places can
+// publish it with SetDefault and read it with Default. Listeners registered with
+// Subscribe are notified when it changes. Code that passes Metric values
+// explicitly is not affected.
+//
+// # Font Scaling
+//
+// Since Android 14, font scales above 1.03 are applied nonlinearly, so large
+// text grows less than small text. Set FontScaling to match what devices render:
+//
+//	m := pxconv.NewMetric(2, 3, 320).WithFontScaling(pxconv.FontScalingNonlinear)
+//	px := m.SpToPx(14) // 44 px rather than 42 px
+//
+// # Baseline Grid
+//
+// SnapLineHeight rounds a line height to whole grid steps in device pixels
+// and returns the padding that puts the first baseline on the grid:
+//
+//	l := metric.SnapLineHeight(pxconv.Baseline{FontSize: 14, LineHeight: 1.5, Grid: 4})
+//
+// # Visual Angle
+//
+// TV and kiosk interfaces are often sized by the angle they cover at a
+// viewing distance. AngleToPx and PixelsPerDegree convert through Dpi:
+//
+//	px := pxconv.AngleToPx(metric, pxconv.Arcmin(20).Degree(), pxconv.Mm(2500))
+//
 // # Features
 //
 // The pxconv package accounts for screen density and user preferences,
@@ -0,0 +1,222 @@
+package pxconv
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+// RoundingMode selects how an exact rational result is rounded to whole pixels.
+type RoundingMode uint8
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest integer, ties away from zero.
+	// This matches math.Round and the float Metric methods.
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds to the nearest integer, ties to the even neighbor.
+	RoundHalfEven
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+	// RoundTowardZero truncates the fractional part.
+	RoundTowardZero
+)
+
+// ExactMetric is the exact counterpart of Metric. Densities and lengths are
+// rational numbers, and every conversion returns an exact *big.Rat, so chains
+// of conversions never drift. Use RoundRat for the final rounding step.
+//
+// Nil, zero or negative densities are treated as 1, and a nil, zero or negative
+// Dpi is treated as the default DPI, mirroring NewMetric.
+type ExactMetric struct {
+	// PxPerDp is the number of pixels per dp unit.
+	PxPerDp *big.Rat
+	// PxPerSp is the number of pixels per sp unit.
+	PxPerSp *big.Rat
+	// Dpi is the screen density in dots per inch.
+	Dpi *big.Rat
+	// Points selects the definition of a point. The zero value is the PostScript point.
+	Points PointSystem
+}
+
+var (
+	ratOne           = big.NewRat(1, 1)
+	ratDefaultDpi    = big.NewRat(consts.DefaultDpi, 1)
+	ratMmPerInch     = big.NewRat(254, 10)
+	ratPointsPerInch = big.NewRat(consts.PointsPerInch, 1)
+	ratTeXPerInch    = big.NewRat(7227, 100)
+	ratEmuPerInch    = big.NewRat(consts.EmuPerInch, 1)
+)
+
+// NewExactMetric creates a new ExactMetric instance. The arguments are copied,
+// so later changes to them do not affect the metric.
+func NewExactMetric(pxPerDp, pxPerSp, dpi *big.Rat) ExactMetric {
+	return ExactMetric{
+		PxPerDp: new(big.Rat).Set(ensurePositiveRat(pxPerDp, ratOne)),
+		PxPerSp: new(big.Rat).Set(ensurePositiveRat(pxPerSp, ratOne)),
+		Dpi:     new(big.Rat).Set(ensurePositiveRat(dpi, ratDefaultDpi)),
+	}
+}
+
+// Exact converts the Metric to an ExactMetric. Each float32 field is read as
+// its shortest decimal representation, so a density of 1.1 becomes exactly 11/10
+// rather than the nearest binary fraction. ExactMetric scales sp linearly, so
+// FontScaling is not carried over.
+func (c Metric) Exact() ExactMetric {
+	m := NewExactMetric(float32ToRat(c.PxPerDp), float32ToRat(c.PxPerSp), float32ToRat(c.Dpi))
+	m.Points = c.Points
+	return m
+}
+
+// Float converts the ExactMetric back to a float32 Metric.
+func (c ExactMetric) Float() Metric {
+	pxPerDp, _ := c.pxPerDp().Float32()
+	pxPerSp, _ := c.pxPerSp().Float32()
+	dpi, _ := c.dpi().Float32()
+	return Metric{PxPerDp: pxPerDp, PxPerSp: pxPerSp, Dpi: dpi, Points: c.Points}
+}
+
+// WithPoints returns a copy of the ExactMetric that interprets points using the given point system.
+func (c ExactMetric) WithPoints(points PointSystem) ExactMetric {
+	c.Points = points
+	return c
+}
+
+// DpToPx converts a dp value to pixels.
+func (c ExactMetric) DpToPx(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(value, c.pxPerDp())
+}
+
+// SpToPx converts an sp value to pixels.
+func (c ExactMetric) SpToPx(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(value, c.pxPerSp())
+}
+
+// PxToDp converts a pixel value to dp.
+func (c ExactMetric) PxToDp(value *big.Rat) *big.Rat {
+	return new(big.Rat).Quo(value, c.pxPerDp())
+}
+
+// PxToSp converts a pixel value to sp.
+func (c ExactMetric) PxToSp(value *big.Rat) *big.Rat {
+	return new(big.Rat).Quo(value, c.pxPerSp())
+}
+
+// DpToSp converts a dp value to sp.
+func (c ExactMetric) DpToSp(value *big.Rat) *big.Rat {
+	return c.PxToSp(c.DpToPx(value))
+}
+
+// SpToDp converts an sp value to dp.
+func (c ExactMetric) SpToDp(value *big.Rat) *big.Rat {
+	return c.PxToDp(c.SpToPx(value))
+}
+
+// InchToPx converts inches to pixels using the current DPI.
+func (c ExactMetric) InchToPx(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(value, c.dpi())
+}
+
+// PxToInch converts pixels to inches using the current DPI.
+func (c ExactMetric) PxToInch(value *big.Rat) *big.Rat {
+	return new(big.Rat).Quo(value, c.dpi())
+}
+
+// MmToPx converts millimeters to pixels using the current DPI.
+func (c ExactMetric) MmToPx(value *big.Rat) *big.Rat {
+	return c.InchToPx(new(big.Rat).Quo(value, ratMmPerInch))
+}
+
+// PxToMm converts pixels to millimeters using the current DPI.
+// For example, with DPI = 96, PxToMm(96) returns exactly 127/5 (25.4).
+func (c ExactMetric) PxToMm(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(c.PxToInch(value), ratMmPerInch)
+}
+
+// PtToPx converts points to pixels using the current DPI and point system.
+func (c ExactMetric) PtToPx(value *big.Rat) *big.Rat {
+	return c.InchToPx(new(big.Rat).Quo(value, c.pointsPerInch()))
+}
+
+// PxToPt converts pixels to points using the current DPI and point system.
+func (c ExactMetric) PxToPt(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(c.PxToInch(value), c.pointsPerInch())
+}
+
+// EmuToPx converts EMU to pixels using the current DPI.
+func (c ExactMetric) EmuToPx(value Emu) *big.Rat {
+	return c.InchToPx(new(big.Rat).SetFrac64(int64(value), consts.EmuPerInch))
+}
+
+// PxToEmu converts pixels to EMU using the current DPI.
+func (c ExactMetric) PxToEmu(value *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(c.PxToInch(value), ratEmuPerInch)
+}
+
+// RoundRat rounds an exact value to an integer using the given rounding mode.
+func RoundRat(value *big.Rat, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).DivMod(value.Num(), value.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	// q is the floor of value and 0 < m < denominator.
+	up := false
+	switch mode {
+	case RoundFloor:
+	case RoundCeil:
+		up = true
+	case RoundTowardZero:
+		up = value.Sign() < 0
+	case RoundHalfEven:
+		cmp := new(big.Int).Lsh(m, 1).Cmp(value.Denom())
+		up = cmp > 0 || cmp == 0 && q.Bit(0) == 1
+	default:
+		cmp := new(big.Int).Lsh(m, 1).Cmp(value.Denom())
+		up = cmp > 0 || cmp == 0 && value.Sign() > 0
+	}
+	if up {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+func (c ExactMetric) pxPerDp() *big.Rat {
+	return ensurePositiveRat(c.PxPerDp, ratOne)
+}
+
+func (c ExactMetric) pxPerSp() *big.Rat {
+	return ensurePositiveRat(c.PxPerSp, ratOne)
+}
+
+func (c ExactMetric) dpi() *big.Rat {
+	return ensurePositiveRat(c.Dpi, ratDefaultDpi)
+}
+
+func (c ExactMetric) pointsPerInch() *big.Rat {
+	switch c.Points {
+	case PointsTeX:
+		return ratTeXPerInch
+	default:
+		return ratPointsPerInch
+	}
+}
+
+// ensurePositiveRat returns value, or fallback if value is nil, zero or negative.
+func ensurePositiveRat(value, fallback *big.Rat) *big.Rat {
+	if value == nil || value.Sign() <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// float32ToRat returns the shortest decimal representation of value as a rational.
+func float32ToRat(value float32) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	if !ok {
+		return nil
+	}
+	return r
+}
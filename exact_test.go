@@ -0,0 +1,88 @@
+package pxconv
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestExactPxToMm checks that exact conversions do not drift.
+func TestExactPxToMm(t *testing.T) {
+	m := Metric{PxPerDp: 2, PxPerSp: 1.5, Dpi: 96}.Exact()
+
+	if res := m.PxToMm(big.NewRat(96, 1)); res.Cmp(big.NewRat(254, 10)) != 0 {
+		t.Errorf("PxToMm(96) = %v; expected 127/5", res)
+	}
+	if res := m.MmToPx(m.PxToMm(big.NewRat(97, 1))); res.Cmp(big.NewRat(97, 1)) != 0 {
+		t.Errorf("MmToPx(PxToMm(97)) = %v; expected 97", res)
+	}
+	if res := m.DpToSp(big.NewRat(3, 1)); res.Cmp(big.NewRat(4, 1)) != 0 {
+		t.Errorf("DpToSp(3) = %v; expected 4", res)
+	}
+	if res := m.PtToPx(big.NewRat(12, 1)); res.Cmp(big.NewRat(16, 1)) != 0 {
+		t.Errorf("PtToPx(12) = %v; expected 16", res)
+	}
+	if res := m.EmuToPx(914400); res.Cmp(big.NewRat(96, 1)) != 0 {
+		t.Errorf("EmuToPx(914400) = %v; expected 96", res)
+	}
+}
+
+// TestExactMetricDecimalDensities checks that float32 fields are read as decimals.
+func TestExactMetricDecimalDensities(t *testing.T) {
+	m := NewMetric(1.1, 1.3, 72.27).Exact()
+
+	if m.PxPerDp.Cmp(big.NewRat(11, 10)) != 0 {
+		t.Errorf("PxPerDp = %v; expected 11/10", m.PxPerDp)
+	}
+	if m.Dpi.Cmp(big.NewRat(7227, 100)) != 0 {
+		t.Errorf("Dpi = %v; expected 7227/100", m.Dpi)
+	}
+	if res := m.WithPoints(PointsTeX).PtToPx(big.NewRat(1, 1)); res.Cmp(ratOne) != 0 {
+		t.Errorf("PtToPx(1) with TeX points = %v; expected 1", res)
+	}
+}
+
+// TestExactMetricDefaults checks that invalid densities fall back to defaults.
+func TestExactMetricDefaults(t *testing.T) {
+	m := NewExactMetric(nil, big.NewRat(-1, 1), big.NewRat(0, 1))
+
+	if m.PxPerDp.Cmp(ratOne) != 0 || m.PxPerSp.Cmp(ratOne) != 0 {
+		t.Errorf("densities = %v, %v; expected 1, 1", m.PxPerDp, m.PxPerSp)
+	}
+	if m.Dpi.Cmp(big.NewRat(96, 1)) != 0 {
+		t.Errorf("Dpi = %v; expected 96", m.Dpi)
+	}
+
+	var zero ExactMetric
+	if res := zero.InchToPx(big.NewRat(1, 1)); res.Cmp(big.NewRat(96, 1)) != 0 {
+		t.Errorf("zero ExactMetric InchToPx(1) = %v; expected 96", res)
+	}
+}
+
+// TestRoundRat checks every rounding mode, including ties and negative values.
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		value    *big.Rat
+		mode     RoundingMode
+		expected int64
+	}{
+		{big.NewRat(5, 2), RoundHalfAwayFromZero, 3},
+		{big.NewRat(-5, 2), RoundHalfAwayFromZero, -3},
+		{big.NewRat(5, 2), RoundHalfEven, 2},
+		{big.NewRat(7, 2), RoundHalfEven, 4},
+		{big.NewRat(-5, 2), RoundHalfEven, -2},
+		{big.NewRat(7, 3), RoundHalfEven, 2},
+		{big.NewRat(-7, 3), RoundFloor, -3},
+		{big.NewRat(7, 3), RoundCeil, 3},
+		{big.NewRat(-7, 3), RoundCeil, -2},
+		{big.NewRat(-7, 3), RoundTowardZero, -2},
+		{big.NewRat(7, 3), RoundTowardZero, 2},
+		{big.NewRat(6, 3), RoundCeil, 2},
+	}
+
+	for _, test := range tests {
+		res := RoundRat(test.value, test.mode)
+		if res.Int64() != test.expected {
+			t.Errorf("RoundRat(%v, %v) = %v; expected %v", test.value, test.mode, res, test.expected)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Package f64 is the float64 counterpart of pxconv. It has the same units,
+// the same Metric methods and the same fallback rules, but stores every length
+// and density as float64, which keeps precision on large canvases such as
+// 2400 dpi print output, maps or poster-size PDFs.
+//
+// Office units (Emu, Twip, HalfPt, Himetric), PointSystem and FontScaling are
+// shared with pxconv, since they are integers or enumerations already.
+package f64
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv"
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+// Dp represents device-independent pixels used for measuring distances on the screen.
+type Dp float64
+
+// Sp represents scale-independent pixels used for measuring font sizes.
+type Sp float64
+
+// Inch represents inches as a unit of measurement.
+type Inch float64
+
+// Mm represents millimeters as a unit of measurement.
+type Mm float64
+
+// Pt represents points as a typographic unit. See pxconv.PointSystem.
+type Pt float64
+
+// Pica represents picas, 12 points each.
+type Pica float64
+
+// Bp represents big points (PostScript points), always 1/72 inch.
+type Bp float64
+
+// TexPt represents TeX points, always 1/72.27 inch.
+type TexPt float64
+
+// Didot represents Didot points as defined by TeX.
+type Didot float64
+
+// Cicero represents ciceros, 12 Didot points each.
+type Cicero float64
+
+// Metric is used to convert screen-independent units (dp, sp) to physical pixels (px).
+type Metric struct {
+	// PxPerDp is the number of pixels per dp unit.
+	PxPerDp float64
+	// PxPerSp is the number of pixels per sp unit.
+	PxPerSp float64
+	// Dpi is the screen density in dots per inch.
+	Dpi float64
+	// Points selects the definition of Pt. The zero value is the PostScript point.
+	Points pxconv.PointSystem
+	// FontScaling selects how sp are scaled. The zero value multiplies by PxPerSp.
+	// Nonlinear lookups use pxconv's float32 tables, as devices do.
+	FontScaling pxconv.FontScaling
+}
+
+// NewMetric creates a new Metric instance, validating input values.
+// Zero or negative densities are replaced with 1, and a zero or negative DPI
+// with the default DPI.
+func NewMetric(pxPerDp, pxPerSp, dpi float64) Metric {
+	if dpi <= 0 {
+		dpi = consts.DefaultDpi
+	}
+	return Metric{
+		PxPerDp: density.EnsurePositive(pxPerDp),
+		PxPerSp: density.EnsurePositive(pxPerSp),
+		Dpi:     dpi,
+	}
+}
+
+// FromMetric converts a float32 pxconv.Metric to a float64 Metric.
+func FromMetric(m pxconv.Metric) Metric {
+	return Metric{
+		PxPerDp:     float64(m.PxPerDp),
+		PxPerSp:     float64(m.PxPerSp),
+		Dpi:         float64(m.Dpi),
+		Points:      m.Points,
+		FontScaling: m.FontScaling,
+	}
+}
+
+// Float32 converts the Metric to a float32 pxconv.Metric.
+func (c Metric) Float32() pxconv.Metric {
+	return pxconv.Metric{
+		PxPerDp:     float32(c.PxPerDp),
+		PxPerSp:     float32(c.PxPerSp),
+		Dpi:         float32(c.Dpi),
+		Points:      c.Points,
+		FontScaling: c.FontScaling,
+	}
+}
+
+// WithPoints returns a copy of the Metric that interprets Pt and Pica using the given point system.
+func (c Metric) WithPoints(points pxconv.PointSystem) Metric {
+	c.Points = points
+	return c
+}
+
+// WithFontScaling returns a copy of the Metric that scales sp using the given mode.
+func (c Metric) WithFontScaling(s pxconv.FontScaling) Metric {
+	c.FontScaling = s
+	return c
+}
+
+// DpToPx converts a dp value to pixels, rounding to the nearest integer.
+func (c Metric) DpToPx(value Dp) int {
+	return int(math.Round(density.EnsurePositive(c.PxPerDp) * float64(value)))
+}
+
+// SpToPx converts an sp value to pixels, rounding to the nearest integer.
+func (c Metric) SpToPx(value Sp) int {
+	if c.nonlinear() {
+		return c.DpToPx(c.SpToDp(value))
+	}
+	return int(math.Round(density.EnsurePositive(c.PxPerSp) * float64(value)))
+}
+
+// DpToSp converts a dp value to sp, using the current density values.
+func (c Metric) DpToSp(value Dp) Sp {
+	if c.nonlinear() {
+		return Sp(c.Float32().DpToSp(pxconv.Dp(value)))
+	}
+	return Sp(float64(value) * density.EnsurePositive(c.PxPerDp) / density.EnsurePositive(c.PxPerSp))
+}
+
+// SpToDp converts an sp value to dp, using the current density values.
+func (c Metric) SpToDp(value Sp) Dp {
+	if c.nonlinear() {
+		return Dp(c.Float32().SpToDp(pxconv.Sp(value)))
+	}
+	return Dp(float64(value) * density.EnsurePositive(c.PxPerSp) / density.EnsurePositive(c.PxPerDp))
+}
+
+// PxToDp converts a pixel value to dp.
+func (c Metric) PxToDp(value int) Dp {
+	return Dp(float64(value) / density.EnsurePositive(c.PxPerDp))
+}
+
+// PxToSp converts a pixel value to sp.
+func (c Metric) PxToSp(value int) Sp {
+	if c.nonlinear() {
+		return c.DpToSp(c.PxToDp(value))
+	}
+	return Sp(float64(value) / density.EnsurePositive(c.PxPerSp))
+}
+
+// InchToPx converts inches to pixels using the current DPI.
+func (c Metric) InchToPx(value Inch) int {
+	return int(math.Round(float64(value) * c.Dpi))
+}
+
+// MmToPx converts millimeters to pixels using the current DPI.
+func (c Metric) MmToPx(value Mm) int {
+	return int(math.Round(float64(value) * c.Dpi / consts.MmPerInch))
+}
+
+// PxToInch converts pixels to inches using the current DPI.
+func (c Metric) PxToInch(value int) Inch {
+	return Inch(float64(value) / c.Dpi)
+}
+
+// PxToMm converts pixels to millimeters using the current DPI.
+func (c Metric) PxToMm(value int) Mm {
+	return Mm(float64(value) * consts.MmPerInch / c.Dpi)
+}
+
+// PtToPx converts points to pixels using the current DPI and point system.
+func (c Metric) PtToPx(value Pt) int {
+	return c.perInchToPx(float64(value), c.pointsPerInch())
+}
+
+// PxToPt converts pixels to points using the current DPI and point system.
+func (c Metric) PxToPt(value int) Pt {
+	return Pt(c.pxToPerInch(value, c.pointsPerInch()))
+}
+
+// PicaToPx converts picas to pixels using the current DPI and point system.
+func (c Metric) PicaToPx(value Pica) int {
+	return c.perInchToPx(float64(value), c.pointsPerInch()/consts.PointsPerPica)
+}
+
+// PxToPica converts pixels to picas using the current DPI and point system.
+func (c Metric) PxToPica(value int) Pica {
+	return Pica(c.pxToPerInch(value, c.pointsPerInch()/consts.PointsPerPica))
+}
+
+// BpToPx converts big points to pixels using the current DPI.
+func (c Metric) BpToPx(value Bp) int {
+	return c.perInchToPx(float64(value), consts.PointsPerInch)
+}
+
+// PxToBp converts pixels to big points using the current DPI.
+func (c Metric) PxToBp(value int) Bp {
+	return Bp(c.pxToPerInch(value, consts.PointsPerInch))
+}
+
+// TexPtToPx converts TeX points to pixels using the current DPI.
+func (c Metric) TexPtToPx(value TexPt) int {
+	return c.perInchToPx(float64(value), consts.TeXPointsPerInch)
+}
+
+// PxToTexPt converts pixels to TeX points using the current DPI.
+func (c Metric) PxToTexPt(value int) TexPt {
+	return TexPt(c.pxToPerInch(value, consts.TeXPointsPerInch))
+}
+
+// DidotToPx converts Didot points to pixels using the current DPI.
+func (c Metric) DidotToPx(value Didot) int {
+	return c.perInchToPx(float64(value), consts.DidotsPerInch)
+}
+
+// PxToDidot converts pixels to Didot points using the current DPI.
+func (c Metric) PxToDidot(value int) Didot {
+	return Didot(c.pxToPerInch(value, consts.DidotsPerInch))
+}
+
+// CiceroToPx converts ciceros to pixels using the current DPI.
+func (c Metric) CiceroToPx(value Cicero) int {
+	return c.perInchToPx(float64(value), consts.DidotsPerInch/consts.DidotsPerCicero)
+}
+
+// PxToCicero converts pixels to ciceros using the current DPI.
+func (c Metric) PxToCicero(value int) Cicero {
+	return Cicero(c.pxToPerInch(value, consts.DidotsPerInch/consts.DidotsPerCicero))
+}
+
+// EmuToPx converts EMU to pixels using the current DPI.
+func (c Metric) EmuToPx(value pxconv.Emu) int {
+	return c.perInchToPx(float64(value), consts.EmuPerInch)
+}
+
+// PxToEmu converts pixels to EMU using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToEmu(value int) pxconv.Emu {
+	return pxconv.Emu(math.Round(c.pxToPerInch(value, consts.EmuPerInch)))
+}
+
+// TwipToPx converts twips to pixels using the current DPI.
+func (c Metric) TwipToPx(value pxconv.Twip) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToTwip converts pixels to twips using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToTwip(value int) pxconv.Twip {
+	return pxconv.Twip(math.Round(c.pxToPerInch(value, consts.EmuPerInch/consts.EmuPerTwip)))
+}
+
+// HalfPtToPx converts half-points to pixels using the current DPI.
+func (c Metric) HalfPtToPx(value pxconv.HalfPt) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToHalfPt converts pixels to half-points using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToHalfPt(value int) pxconv.HalfPt {
+	return pxconv.HalfPt(math.Round(c.pxToPerInch(value, consts.EmuPerInch/consts.EmuPerHalfPoint)))
+}
+
+// HimetricToPx converts HIMETRIC units to pixels using the current DPI.
+func (c Metric) HimetricToPx(value pxconv.Himetric) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToHimetric converts pixels to HIMETRIC units using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToHimetric(value int) pxconv.Himetric {
+	return pxconv.Himetric(math.Round(c.pxToPerInch(value, consts.EmuPerInch/consts.EmuPerHimetric)))
+}
+
+// GetDensity returns the current density values (PxPerDp and PxPerSp).
+func (c Metric) GetDensity() (float64, float64) {
+	return c.PxPerDp, c.PxPerSp
+}
+
+// nonlinear reports whether sp are scaled through pxconv's font scale tables.
+// Otherwise sp stay linear and keep float64 precision.
+func (c Metric) nonlinear() bool {
+	return c.Float32().NonlinearFontScaling()
+}
+
+func (c Metric) pointsPerInch() float64 {
+	switch c.Points {
+	case pxconv.PointsTeX:
+		return consts.TeXPointsPerInch
+	default:
+		return consts.PointsPerInch
+	}
+}
+
+// perInchToPx converts a value of a unit with perInch units per inch to pixels.
+func (c Metric) perInchToPx(value, perInch float64) int {
+	return int(math.Round(value * c.Dpi / perInch))
+}
+
+// pxToPerInch converts pixels to a unit with perInch units per inch.
+func (c Metric) pxToPerInch(value int, perInch float64) float64 {
+	return float64(value) * perInch / c.Dpi
+}
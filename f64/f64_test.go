@@ -0,0 +1,101 @@
+package f64
+
+import (
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestNewMetricDefaults checks that invalid values are replaced like in pxconv.
+func TestNewMetricDefaults(t *testing.T) {
+	m := NewMetric(0, -5, 0)
+
+	if m.PxPerDp != 1 || m.PxPerSp != 1 || m.Dpi != 96 {
+		t.Errorf("NewMetric(0, -5, 0) = %+v; expected densities 1 and Dpi 96", m)
+	}
+}
+
+// TestMatchesFloat32API checks that results agree with pxconv for ordinary values.
+func TestMatchesFloat32API(t *testing.T) {
+	m32 := pxconv.NewMetric(2, 1.5, 96)
+	m := FromMetric(m32)
+
+	for i := 0; i < 1000; i++ {
+		if got, want := m.DpToPx(Dp(i)), m32.DpToPx(pxconv.Dp(i)); got != want {
+			t.Fatalf("DpToPx(%v) = %v; pxconv returns %v", i, got, want)
+		}
+		if got, want := m.SpToPx(Sp(i)), m32.SpToPx(pxconv.Sp(i)); got != want {
+			t.Fatalf("SpToPx(%v) = %v; pxconv returns %v", i, got, want)
+		}
+		if got, want := m.PtToPx(Pt(i)), m32.PtToPx(pxconv.Pt(i)); got != want {
+			t.Fatalf("PtToPx(%v) = %v; pxconv returns %v", i, got, want)
+		}
+		if got, want := m.MmToPx(Mm(i)), m32.MmToPx(pxconv.Mm(i)); got != want {
+			t.Fatalf("MmToPx(%v) = %v; pxconv returns %v", i, got, want)
+		}
+	}
+	if m.Float32() != m32 {
+		t.Errorf("Float32() = %+v; expected %+v", m.Float32(), m32)
+	}
+}
+
+// TestLargeCanvasPrecision checks that large print canvases keep precision.
+func TestLargeCanvasPrecision(t *testing.T) {
+	m := NewMetric(1, 1, 2400)
+	tests := []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{"1189 mm (A0 height)", m.MmToPx(1189), 112346},
+		{"100 inch", m.InchToPx(100), 240000},
+		{"72 pt", m.PtToPx(72), 2400},
+		{"1440 twips", m.TwipToPx(1440), 2400},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s = %vpx; expected %v", test.name, test.got, test.expected)
+		}
+	}
+
+	if res := m.PxToMm(240000); res != 2540 {
+		t.Errorf("PxToMm(240000) = %v; expected 2540", res)
+	}
+	if res := m.PxToDp(16777217); res != 16777217 {
+		t.Errorf("PxToDp(16777217) = %v; expected 16777217", res)
+	}
+}
+
+// TestTeXPoints checks that the point system is honored.
+func TestTeXPoints(t *testing.T) {
+	m := NewMetric(1, 1, 72.27).WithPoints(pxconv.PointsTeX)
+
+	if res := m.PtToPx(100); res != 100 {
+		t.Errorf("PtToPx(100) = %v; expected 100", res)
+	}
+	if res := m.PxToTexPt(100); res != 100 {
+		t.Errorf("PxToTexPt(100) = %v; expected 100", res)
+	}
+}
+
+// TestNonlinearFontScaling checks that nonlinear sp match pxconv.
+func TestNonlinearFontScaling(t *testing.T) {
+	m32 := pxconv.NewMetric(2, 3, 320).WithFontScaling(pxconv.FontScalingNonlinear)
+	m := FromMetric(m32)
+	if m.Float32() != m32 {
+		t.Fatalf("Float32() = %+v; expected %+v", m.Float32(), m32)
+	}
+
+	for i := 0; i < 200; i++ {
+		if res, expected := m.SpToPx(Sp(i)), m32.SpToPx(pxconv.Sp(i)); res != expected {
+			t.Fatalf("SpToPx(%v) = %v; expected %v", i, res, expected)
+		}
+		if res, expected := m.PxToSp(i), m32.PxToSp(i); float32(res) != float32(expected) {
+			t.Fatalf("PxToSp(%v) = %v; expected %v", i, res, expected)
+		}
+	}
+	if res := m.WithFontScaling(pxconv.FontScalingLinear).SpToPx(14); res != 42 {
+		t.Errorf("linear SpToPx(14) = %d; expected 42", res)
+	}
+}
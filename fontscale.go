@@ -0,0 +1,134 @@
+package pxconv
+
+import "github.com/MiCkEyZzZ/pxconv/internal/density"
+
+// FontScaling selects how a Metric scales sp to pixels.
+type FontScaling uint8
+
+const (
+	// FontScalingLinear multiplies sp by PxPerSp. It is the zero value, so
+	// existing code keeps its behavior.
+	FontScalingLinear FontScaling = iota
+	// FontScalingNonlinear applies the nonlinear font scaling introduced in
+	// Android 14, where large text grows less than small text. The font scale
+	// is PxPerSp / PxPerDp. From 1.03 up to 2, sp are mapped to dp through the
+	// platform's lookup tables, interpolating between them, and then multiplied
+	// by PxPerDp. Other font scales stay linear, as they do on the device.
+	FontScalingNonlinear
+)
+
+// String returns the name of the font scaling mode.
+func (s FontScaling) String() string {
+	switch s {
+	case FontScalingNonlinear:
+		return "nonlinear"
+	default:
+		return "linear"
+	}
+}
+
+// minNonlinearFontScale is the smallest font scale Android scales nonlinearly.
+const minNonlinearFontScale = 1.03
+
+// fontTable holds one dp value for each of the sp sizes in fontSizes.
+type fontTable [9]float32
+
+// fontSizes are the sp sizes of Android's font scale lookup tables.
+var fontSizes = fontTable{8, 10, 12, 14, 18, 20, 24, 30, 100}
+
+// fontScaleTables are Android's lookup tables from FontScaleConverterFactory,
+// in increasing order of font scale.
+var fontScaleTables = []struct {
+	scale float32
+	dp    fontTable
+}{
+	{1.15, fontTable{9.2, 11.5, 13.8, 16.4, 19.8, 21.8, 25.2, 30, 100}},
+	{1.3, fontTable{10.4, 13, 15.6, 18.8, 21.6, 23.6, 26.4, 30, 100}},
+	{1.5, fontTable{12, 15, 18, 22, 24, 26, 28, 30, 100}},
+	{1.8, fontTable{14.4, 18, 21.6, 24.4, 27.6, 30.8, 32.8, 34.8, 100}},
+	{2, fontTable{16, 20, 24, 26, 30, 34, 36, 38, 100}},
+}
+
+// WithFontScaling returns a copy of the Metric that scales sp using the given mode.
+func (c Metric) WithFontScaling(s FontScaling) Metric {
+	c.FontScaling = s
+	return c
+}
+
+// FontScale returns the user's font scale, PxPerSp / PxPerDp.
+func (c Metric) FontScale() float32 {
+	return density.EnsurePositive(c.PxPerSp) / density.EnsurePositive(c.PxPerDp)
+}
+
+// NonlinearFontScaling reports whether sp are mapped through Android's
+// nonlinear lookup tables: FontScaling is FontScalingNonlinear and the font
+// scale is in the range the tables cover.
+func (c Metric) NonlinearFontScaling() bool {
+	_, ok := c.fontTable()
+	return ok
+}
+
+// fontTable returns the sp to dp table for the Metric's font scale, or false
+// if sp scale linearly. Between two of Android's tables, and between 1 and
+// the first one, the dp values are interpolated like the platform does.
+func (c Metric) fontTable() (fontTable, bool) {
+	if c.FontScaling != FontScalingNonlinear {
+		return fontTable{}, false
+	}
+	scale := c.FontScale()
+	if scale < minNonlinearFontScale {
+		return fontTable{}, false
+	}
+	lowScale, low := float32(1), fontSizes
+	for _, t := range fontScaleTables {
+		if scale == t.scale {
+			return t.dp, true
+		}
+		if scale < t.scale {
+			f := (scale - lowScale) / (t.scale - lowScale)
+			var dp fontTable
+			for i := range dp {
+				dp[i] = low[i] + (t.dp[i]-low[i])*f
+			}
+			return dp, true
+		}
+		lowScale, low = t.scale, t.dp
+	}
+	return fontTable{}, false
+}
+
+// spToDp maps sp to dp through the table.
+func (t *fontTable) spToDp(value Sp) Dp {
+	return Dp(lookup(float32(value), &fontSizes, t))
+}
+
+// dpToSp maps dp to sp through the table, the inverse of spToDp.
+func (t *fontTable) dpToSp(value Dp) Sp {
+	return Sp(lookup(float32(value), t, &fontSizes))
+}
+
+// lookup maps value from the from column to the to column, interpolating
+// linearly between entries. Below the first entry it interpolates from zero,
+// and above the last it scales by the last ratio. Negative values are mapped
+// by magnitude and keep their sign.
+func lookup(value float32, from, to *fontTable) float32 {
+	sign, abs := float32(1), value
+	if value < 0 {
+		sign, abs = -1, -value
+	}
+	last := len(from) - 1
+	if !(abs < from[last]) {
+		return value * (to[last] / from[last])
+	}
+	var x0, y0 float32
+	for i, x := range from {
+		if abs == x {
+			return sign * to[i]
+		}
+		if abs < x {
+			return sign * (y0 + (to[i]-y0)*((abs-x0)/(x-x0)))
+		}
+		x0, y0 = x, to[i]
+	}
+	return value
+}
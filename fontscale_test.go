@@ -0,0 +1,173 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestFontScalingString checks the names of the FontScaling modes.
+func TestFontScalingString(t *testing.T) {
+	tests := []struct {
+		mode     FontScaling
+		expected string
+	}{
+		{FontScalingLinear, "linear"},
+		{FontScalingNonlinear, "nonlinear"},
+	}
+
+	for _, test := range tests {
+		if res := test.mode.String(); res != test.expected {
+			t.Errorf("FontScaling(%d).String() = %q; expected %q", test.mode, res, test.expected)
+		}
+	}
+}
+
+// TestNonlinearFontScaling checks the font scales that use the lookup tables.
+func TestNonlinearFontScaling(t *testing.T) {
+	tests := []struct {
+		m        Metric
+		expected bool
+	}{
+		{NewMetric(1, 2, 160), false},
+		{NewMetric(1, 1.02, 160).WithFontScaling(FontScalingNonlinear), false},
+		{NewMetric(1, 1.03, 160).WithFontScaling(FontScalingNonlinear), true},
+		{NewMetric(2, 3, 320).WithFontScaling(FontScalingNonlinear), true},
+		{NewMetric(1, 2, 160).WithFontScaling(FontScalingNonlinear), true},
+		{NewMetric(1, 2.01, 160).WithFontScaling(FontScalingNonlinear), false},
+	}
+
+	for _, test := range tests {
+		if res := test.m.NonlinearFontScaling(); res != test.expected {
+			t.Errorf("NonlinearFontScaling() at font scale %v (%v) = %v; expected %v", test.m.FontScale(), test.m.FontScaling, res, test.expected)
+		}
+	}
+}
+
+// TestNonlinearSpToDp checks values from and between Android's tables.
+func TestNonlinearSpToDp(t *testing.T) {
+	tests := []struct {
+		name     string
+		pxPerSp  float32
+		sp       Sp
+		expected Dp
+	}{
+		{"2x table", 2, 14, 26},
+		{"2x first", 2, 8, 16},
+		{"2x below first", 2, 4, 8},
+		{"2x between", 2, 50, 38 + 62*20.0/70},
+		{"2x last", 2, 100, 100},
+		{"2x beyond", 2, 200, 200},
+		{"2x negative", 2, -14, -26},
+		{"1.5x table", 1.5, 14, 22},
+		{"1.4x between tables", 1.4, 14, (18.8 + 22) / 2},
+		{"1.075x from identity", 1.075, 8, 8.6},
+		{"1.02x linear", 1.02, 50, 51},
+		{"2.5x linear", 2.5, 50, 125},
+		{"0.85x linear", 0.85, 20, 17},
+	}
+
+	for _, test := range tests {
+		m := NewMetric(1, test.pxPerSp, 160).WithFontScaling(FontScalingNonlinear)
+		if res := m.SpToDp(test.sp); math.Abs(float64(res-test.expected)) > 1e-4 {
+			t.Errorf("%s: SpToDp(%v) = %v; expected %v", test.name, test.sp, res, test.expected)
+		}
+	}
+}
+
+// TestNonlinearSpToPx checks that density is applied after the table.
+func TestNonlinearSpToPx(t *testing.T) {
+	m := NewMetric(2, 3, 320)
+	if res := m.SpToPx(14); res != 42 {
+		t.Errorf("linear SpToPx(14) = %d; expected 42", res)
+	}
+	m = m.WithFontScaling(FontScalingNonlinear)
+	if res := m.SpToPx(14); res != 44 {
+		t.Errorf("nonlinear SpToPx(14) = %d; expected 44", res)
+	}
+	if res := m.SpToPx(30); res != 60 {
+		t.Errorf("nonlinear SpToPx(30) = %d; expected 60", res)
+	}
+	if res := m.PxToSp(44); math.Abs(float64(res)-14) > 1e-5 {
+		t.Errorf("nonlinear PxToSp(44) = %v; expected 14", res)
+	}
+	if res := m.DpToSp(15); math.Abs(float64(res)-10) > 1e-5 {
+		t.Errorf("nonlinear DpToSp(15) = %v; expected 10", res)
+	}
+	if res := m.FontScale(); res != 1.5 {
+		t.Errorf("FontScale() = %v; expected 1.5", res)
+	}
+}
+
+// TestNonlinearConsistency checks that every sp path agrees with the Metric methods.
+func TestNonlinearConsistency(t *testing.T) {
+	m := NewMetric(2.625, 4.2, 420).WithFontScaling(FontScalingNonlinear)
+	conv := NewConverterWithTable(m, 64)
+	plain := NewConverter(m)
+	src := []Sp{-3, 0, 7.5, 8, 11, 14, 22, 40, 63, 150}
+	px := make([]int, len(src))
+	m.SpToPxSlice(px, src)
+	generic := make([]int, len(src))
+	ToPxSlice(m, generic, src)
+
+	for i, v := range src {
+		expected := m.SpToPx(v)
+		for name, res := range map[string]int{
+			"Converter": conv.SpToPx(v), "Converter without table": plain.SpToPx(v),
+			"SpToPxSlice": px[i], "ToPxSlice": generic[i], "ToPx": ToPx(m, v),
+			"LengthToPx": m.LengthToPx(Length{Value: float32(v), Unit: UnitSp}),
+		} {
+			if res != expected {
+				t.Errorf("%s(%v) = %d; expected %d", name, v, res, expected)
+			}
+		}
+		dp := m.SpToDp(v)
+		if res := conv.SpToDp(v); res != dp {
+			t.Errorf("Converter.SpToDp(%v) = %v; expected %v", v, res, dp)
+		}
+		if res := To[Dp](m, v); math.Abs(float64(res-dp)) > 1e-4 {
+			t.Errorf("To[Dp](%v) = %v; expected %v", v, res, dp)
+		}
+		l := m.ConvertLength(Length{Value: float32(v), Unit: UnitSp}, UnitDp)
+		if math.Abs(float64(l.Value-float32(dp))) > 1e-4 {
+			t.Errorf("ConvertLength(%vsp) = %v; expected %v", v, l, dp)
+		}
+	}
+
+	for _, p := range []int{-10, 0, 21, 58, 100, 400} {
+		expected := m.PxToSp(p)
+		sp := make([]Sp, 1)
+		m.PxToSpSlice(sp, []int{p})
+		generic := make([]Sp, 1)
+		FromPxSlice(m, generic, []int{p})
+		for name, res := range map[string]Sp{
+			"Converter": conv.PxToSp(p), "PxToSpSlice": sp[0], "FromPxSlice": generic[0], "FromPx": FromPx[Sp](m, p),
+		} {
+			if math.Abs(float64(res-expected)) > 1e-4 {
+				t.Errorf("%s(%d) = %v; expected %v", name, p, res, expected)
+			}
+		}
+		if res := m.ConvertLength(Length{Value: float32(p), Unit: UnitPx}, UnitSp).Value; math.Abs(float64(res)-float64(expected)) > 1e-4 {
+			t.Errorf("ConvertLength(%dpx) = %v; expected %v", p, res, expected)
+		}
+	}
+}
+
+// TestPropNonlinearInverse checks that DpToSp inverts SpToDp and that
+// nonlinear sizes are monotonic.
+func TestPropNonlinearInverse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		scale := rapid.Float32Range(1, 2).Draw(t, "scale")
+		m := NewMetric(1, scale, 160).WithFontScaling(FontScalingNonlinear)
+		sp := Sp(rapid.Float32Range(0, 200).Draw(t, "sp"))
+
+		dp := m.SpToDp(sp)
+		if back := m.DpToSp(dp); math.Abs(float64(back-sp)) > 1e-3*math.Max(1, float64(sp)) {
+			t.Fatalf("DpToSp(SpToDp(%v)) = %v", sp, back)
+		}
+		if m.SpToDp(sp+1) < dp {
+			t.Fatalf("SpToDp is not monotonic at %v", sp)
+		}
+	})
+}
@@ -8,3 +8,28 @@ const (
 	// PointsPerInch is the number of points in one inch.
 	PointsPerInch = 72
 )
+
+// Office document units. Every unit below is an integer multiple of an EMU,
+// which keeps conversions between them exact.
+const (
+	// EmuPerInch is the number of English Metric Units in one inch.
+	EmuPerInch = 914400
+	// EmuPerTwip is the number of EMU in one twip (1/1440 inch).
+	EmuPerTwip = 635
+	// EmuPerHalfPoint is the number of EMU in one half-point (1/144 inch).
+	EmuPerHalfPoint = 6350
+	// EmuPerHimetric is the number of EMU in one HIMETRIC unit (0.01 mm).
+	EmuPerHimetric = 360
+)
+
+// Traditional typographic units, using the definitions from TeX.
+const (
+	// TeXPointsPerInch is the number of TeX points in one inch.
+	TeXPointsPerInch = 72.27
+	// PointsPerPica is the number of points in one pica.
+	PointsPerPica = 12
+	// DidotsPerInch is the number of Didot points in one inch (1dd = 1238/1157 TeX pt).
+	DidotsPerInch = TeXPointsPerInch * 1157 / 1238
+	// DidotsPerCicero is the number of Didot points in one cicero.
+	DidotsPerCicero = 12
+)
@@ -2,7 +2,7 @@ package density
 
 // EnsurePositive returns a positive value.
 // If the input is zero or negative, it returns 1.
-func EnsurePositive(value float32) float32 {
+func EnsurePositive[T float32 | float64](value T) T {
 	if value <= 0 {
 		return 1
 	}
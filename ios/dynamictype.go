@@ -0,0 +1,197 @@
+// Package ios models iOS Dynamic Type: the content size categories a user can
+// choose and the point size of each system text style in each category.
+//
+// iOS points are logical points, the same unit as dp, so conversions to device
+// pixels use Metric.PxPerDp (the screen scale, such as 2 or 3) rather than Dpi.
+package ios
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// ErrUnknown is returned when a category or text style name is not recognized.
+var ErrUnknown = errors.New("ios: unknown name")
+
+// Category is a Dynamic Type content size category, from XSmall to AX5.
+// Categories are ordered, so larger text compares greater.
+type Category uint8
+
+const (
+	// XSmall is UIContentSizeCategory.extraSmall.
+	XSmall Category = iota
+	// Small is UIContentSizeCategory.small.
+	Small
+	// Medium is UIContentSizeCategory.medium.
+	Medium
+	// Large is UIContentSizeCategory.large, the system default.
+	Large
+	// XLarge is UIContentSizeCategory.extraLarge.
+	XLarge
+	// XXLarge is UIContentSizeCategory.extraExtraLarge.
+	XXLarge
+	// XXXLarge is UIContentSizeCategory.extraExtraExtraLarge, the largest
+	// category without the accessibility setting.
+	XXXLarge
+	// AX1 is UIContentSizeCategory.accessibilityMedium.
+	AX1
+	// AX2 is UIContentSizeCategory.accessibilityLarge.
+	AX2
+	// AX3 is UIContentSizeCategory.accessibilityExtraLarge.
+	AX3
+	// AX4 is UIContentSizeCategory.accessibilityExtraExtraLarge.
+	AX4
+	// AX5 is UIContentSizeCategory.accessibilityExtraExtraExtraLarge.
+	AX5
+)
+
+// Categories lists every category, smallest first.
+var Categories = []Category{XSmall, Small, Medium, Large, XLarge, XXLarge, XXXLarge, AX1, AX2, AX3, AX4, AX5}
+
+var categoryNames = [...]struct{ short, swift string }{
+	{"xSmall", "extraSmall"},
+	{"small", "small"},
+	{"medium", "medium"},
+	{"large", "large"},
+	{"xLarge", "extraLarge"},
+	{"xxLarge", "extraExtraLarge"},
+	{"xxxLarge", "extraExtraExtraLarge"},
+	{"AX1", "accessibilityMedium"},
+	{"AX2", "accessibilityLarge"},
+	{"AX3", "accessibilityExtraLarge"},
+	{"AX4", "accessibilityExtraExtraLarge"},
+	{"AX5", "accessibilityExtraExtraExtraLarge"},
+}
+
+// String returns the short name of the category, such as "xxLarge" or "AX1".
+func (c Category) String() string {
+	if int(c) >= len(categoryNames) {
+		return fmt.Sprintf("Category(%d)", uint8(c))
+	}
+	return categoryNames[c].short
+}
+
+// IsAccessibility reports whether the category is one of the accessibility
+// sizes, AX1 to AX5.
+func (c Category) IsAccessibility() bool {
+	return c >= AX1 && c <= AX5
+}
+
+// ParseCategory parses a category name, either the short form used by String
+// or the UIContentSizeCategory member name, such as "extraLarge" or
+// "accessibilityMedium". Case is ignored.
+func ParseCategory(s string) (Category, error) {
+	for i, n := range categoryNames {
+		if strings.EqualFold(s, n.short) || strings.EqualFold(s, n.swift) {
+			return Category(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: category %q", ErrUnknown, s)
+}
+
+// TextStyle is a system text style, such as UIFont.TextStyle.body.
+type TextStyle uint8
+
+const (
+	// LargeTitle is UIFont.TextStyle.largeTitle.
+	LargeTitle TextStyle = iota
+	// Title1 is UIFont.TextStyle.title1.
+	Title1
+	// Title2 is UIFont.TextStyle.title2.
+	Title2
+	// Title3 is UIFont.TextStyle.title3.
+	Title3
+	// Headline is UIFont.TextStyle.headline.
+	Headline
+	// Body is UIFont.TextStyle.body.
+	Body
+	// Callout is UIFont.TextStyle.callout.
+	Callout
+	// Subheadline is UIFont.TextStyle.subheadline.
+	Subheadline
+	// Footnote is UIFont.TextStyle.footnote.
+	Footnote
+	// Caption1 is UIFont.TextStyle.caption1.
+	Caption1
+	// Caption2 is UIFont.TextStyle.caption2.
+	Caption2
+)
+
+// TextStyles lists every text style, largest first.
+var TextStyles = []TextStyle{LargeTitle, Title1, Title2, Title3, Headline, Body, Callout, Subheadline, Footnote, Caption1, Caption2}
+
+var textStyleNames = [...]string{
+	"largeTitle", "title1", "title2", "title3", "headline", "body",
+	"callout", "subheadline", "footnote", "caption1", "caption2",
+}
+
+// String returns the UIFont.TextStyle member name, such as "body".
+func (s TextStyle) String() string {
+	if int(s) >= len(textStyleNames) {
+		return fmt.Sprintf("TextStyle(%d)", uint8(s))
+	}
+	return textStyleNames[s]
+}
+
+// ParseTextStyle parses a UIFont.TextStyle member name, such as "title2".
+// Case is ignored.
+func ParseTextStyle(s string) (TextStyle, error) {
+	for i, n := range textStyleNames {
+		if strings.EqualFold(s, n) {
+			return TextStyle(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: text style %q", ErrUnknown, s)
+}
+
+// sizes holds the point size of each text style (rows) in each category
+// (columns), from Apple's Human Interface Guidelines.
+var sizes = [...][len(categoryNames)]float32{
+	LargeTitle:  {31, 32, 33, 34, 36, 38, 40, 44, 48, 52, 56, 60},
+	Title1:      {25, 26, 27, 28, 30, 32, 34, 38, 43, 48, 53, 58},
+	Title2:      {19, 20, 21, 22, 24, 26, 28, 34, 39, 44, 50, 56},
+	Title3:      {17, 18, 19, 20, 22, 24, 26, 31, 37, 43, 49, 55},
+	Headline:    {14, 15, 16, 17, 19, 21, 23, 28, 33, 40, 47, 53},
+	Body:        {14, 15, 16, 17, 19, 21, 23, 28, 33, 40, 47, 53},
+	Callout:     {13, 14, 15, 16, 18, 20, 22, 26, 32, 38, 44, 51},
+	Subheadline: {12, 13, 14, 15, 17, 19, 21, 25, 30, 36, 42, 49},
+	Footnote:    {12, 12, 12, 13, 15, 17, 19, 23, 27, 33, 38, 44},
+	Caption1:    {11, 11, 11, 12, 14, 16, 18, 22, 26, 32, 37, 43},
+	Caption2:    {11, 11, 11, 11, 13, 15, 17, 20, 24, 29, 34, 40},
+}
+
+// Size returns the point size of a text style in a category. For example,
+// Size(Body, Large) returns 17 and Size(Body, AX5) returns 53. It returns 0 for
+// an unknown style or category.
+func Size(style TextStyle, category Category) pxconv.Pt {
+	if int(style) >= len(sizes) || int(category) >= len(categoryNames) {
+		return 0
+	}
+	return pxconv.Pt(sizes[style][category])
+}
+
+// Px returns the size of a text style in a category in device pixels, rounding
+// to the nearest integer. For example, Body at AX1 on a 3x screen is 84px.
+func Px(m pxconv.Metric, style TextStyle, category Category) int {
+	return m.DpToPx(pxconv.Dp(Size(style, category)))
+}
+
+// Scale returns how much a category scales a text style relative to Large.
+// For example, Scale(Body, AX5) returns 53/17.
+func Scale(style TextStyle, category Category) float32 {
+	base := Size(style, Large)
+	if base == 0 {
+		return 0
+	}
+	return float32(Size(style, category) / base)
+}
+
+// ScaledValue scales a custom size the way UIFontMetrics(forTextStyle:)
+// scaledValue(for:) does: by the ratio of the style's size in category to its
+// size in Large.
+func ScaledValue(style TextStyle, category Category, value pxconv.Pt) pxconv.Pt {
+	return value * pxconv.Pt(Scale(style, category))
+}
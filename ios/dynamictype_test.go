@@ -0,0 +1,119 @@
+package ios
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestSize checks Apple's point sizes for several styles and categories and
+// the zero result for unknown values.
+func TestSize(t *testing.T) {
+	tests := []struct {
+		style    TextStyle
+		category Category
+		expected pxconv.Pt
+	}{
+		{Body, Large, 17},
+		{Body, XSmall, 14},
+		{Body, XXXLarge, 23},
+		{Body, AX5, 53},
+		{LargeTitle, Large, 34},
+		{LargeTitle, AX5, 60},
+		{Caption2, Medium, 11},
+		{Caption2, AX1, 20},
+		{Footnote, Small, 12},
+		{TextStyle(99), Large, 0},
+		{Body, Category(99), 0},
+	}
+
+	for _, test := range tests {
+		if res := Size(test.style, test.category); res != test.expected {
+			t.Errorf("Size(%v, %v) = %v; expected %v", test.style, test.category, res, test.expected)
+		}
+	}
+}
+
+// TestSizeMonotonic checks that every style grows with the category.
+func TestSizeMonotonic(t *testing.T) {
+	for _, s := range TextStyles {
+		for i := 1; i < len(Categories); i++ {
+			if Size(s, Categories[i]) < Size(s, Categories[i-1]) {
+				t.Errorf("%v shrinks from %v to %v", s, Categories[i-1], Categories[i])
+			}
+		}
+	}
+}
+
+// TestPx checks the conversion of Dynamic Type sizes to device pixels.
+func TestPx(t *testing.T) {
+	tests := []struct {
+		m        pxconv.Metric
+		style    TextStyle
+		category Category
+		expected int
+	}{
+		{pxconv.NewMetric(3, 3, 460), Body, AX1, 84},
+		{pxconv.NewMetric(2, 2, 326), Headline, Large, 34},
+	}
+
+	for _, test := range tests {
+		if res := Px(test.m, test.style, test.category); res != test.expected {
+			t.Errorf("Px(%v, %v) = %d; expected %d", test.style, test.category, res, test.expected)
+		}
+	}
+}
+
+// TestScale checks scale factors relative to the Large category and scaled
+// custom values.
+func TestScale(t *testing.T) {
+	tests := []struct {
+		style    TextStyle
+		category Category
+		expected float32
+	}{
+		{Body, Large, 1},
+		{Body, AX5, float32(53) / 17},
+		{TextStyle(99), AX5, 0},
+	}
+
+	for _, test := range tests {
+		if res := Scale(test.style, test.category); res != test.expected {
+			t.Errorf("Scale(%v, %v) = %v; expected %v", test.style, test.category, res, test.expected)
+		}
+	}
+	if res := ScaledValue(Title1, XXXLarge, 14); res != 17 {
+		t.Errorf("ScaledValue(Title1, XXXLarge, 14) = %v; expected 17", res)
+	}
+}
+
+// TestParse checks that names round-trip through ParseCategory and
+// ParseTextStyle and that unknown names are rejected.
+func TestParse(t *testing.T) {
+	for _, c := range Categories {
+		if res, err := ParseCategory(c.String()); err != nil || res != c {
+			t.Errorf("ParseCategory(%q) = %v, %v; expected %v", c, res, err, c)
+		}
+	}
+	if res, err := ParseCategory("AccessibilityExtraExtraExtraLarge"); err != nil || res != AX5 {
+		t.Errorf("ParseCategory(AccessibilityExtraExtraExtraLarge) = %v, %v; expected AX5", res, err)
+	}
+	if !AX1.IsAccessibility() || XXXLarge.IsAccessibility() {
+		t.Error("IsAccessibility is wrong at the AX1 boundary")
+	}
+	for _, s := range TextStyles {
+		if res, err := ParseTextStyle(s.String()); err != nil || res != s {
+			t.Errorf("ParseTextStyle(%q) = %v, %v; expected %v", s, res, err, s)
+		}
+	}
+	if _, err := ParseCategory("huge"); !errors.Is(err, ErrUnknown) {
+		t.Errorf("ParseCategory(huge) error = %v; expected ErrUnknown", err)
+	}
+	if _, err := ParseTextStyle("display"); !errors.Is(err, ErrUnknown) {
+		t.Errorf("ParseTextStyle(display) error = %v; expected ErrUnknown", err)
+	}
+	if res := Category(99).String(); res != "Category(99)" {
+		t.Errorf("Category(99).String() = %q; expected %q", res, "Category(99)")
+	}
+}
@@ -0,0 +1,142 @@
+package pxconv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidLength is returned by ParseLength for a malformed length.
+var ErrInvalidLength = errors.New("pxconv: invalid length")
+
+// LengthUnit identifies the unit of a Length.
+type LengthUnit uint8
+
+const (
+	// UnitPx is pixels.
+	UnitPx LengthUnit = iota
+	// UnitDp is density-independent pixels.
+	UnitDp
+	// UnitSp is scale-independent pixels.
+	UnitSp
+	// UnitPt is points, as selected by Metric.Points.
+	UnitPt
+	// UnitInch is inches.
+	UnitInch
+	// UnitMm is millimeters.
+	UnitMm
+)
+
+// unitSuffixes maps the suffixes accepted by ParseLength to units.
+var unitSuffixes = map[string]LengthUnit{
+	"px":  UnitPx,
+	"dp":  UnitDp,
+	"dip": UnitDp,
+	"sp":  UnitSp,
+	"pt":  UnitPt,
+	"in":  UnitInch,
+	"mm":  UnitMm,
+}
+
+// String returns the suffix of the unit, such as "dp".
+func (u LengthUnit) String() string {
+	switch u {
+	case UnitDp:
+		return "dp"
+	case UnitSp:
+		return "sp"
+	case UnitPt:
+		return "pt"
+	case UnitInch:
+		return "in"
+	case UnitMm:
+		return "mm"
+	default:
+		return "px"
+	}
+}
+
+// pxPerUnit returns the number of pixels in one unit under m.
+func (u LengthUnit) pxPerUnit(m Metric) float64 {
+	switch u {
+	case UnitDp:
+		return Dp(0).PxPerUnit(m)
+	case UnitSp:
+		return Sp(0).PxPerUnit(m)
+	case UnitPt:
+		return Pt(0).PxPerUnit(m)
+	case UnitInch:
+		return Inch(0).PxPerUnit(m)
+	case UnitMm:
+		return Mm(0).PxPerUnit(m)
+	default:
+		return 1
+	}
+}
+
+// Length is a value together with its unit, for lengths whose unit is only
+// known at run time, such as values read from resource or token files.
+type Length struct {
+	Value float32
+	Unit  LengthUnit
+}
+
+// ParseLength parses a length such as "16dp", "1.5in" or "12 sp". The accepted
+// units are px, dp, dip, sp, pt, in and mm.
+func ParseLength(s string) (Length, error) {
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexAny(s, "0123456789.")
+	unit, ok := unitSuffixes[strings.ToLower(strings.TrimSpace(s[i+1:]))]
+	if !ok {
+		return Length{}, fmt.Errorf("%w: %q", ErrInvalidLength, s)
+	}
+	v, err := strconv.ParseFloat(s[:i+1], 32)
+	if err != nil {
+		return Length{}, fmt.Errorf("%w: %q", ErrInvalidLength, s)
+	}
+	return Length{Value: float32(v), Unit: unit}, nil
+}
+
+// String formats the length with its unit suffix, such as "16dp".
+func (l Length) String() string {
+	return strconv.FormatFloat(float64(l.Value), 'f', -1, 32) + l.Unit.String()
+}
+
+// LengthToPx converts a length to pixels, rounding to the nearest integer.
+// Dp, sp, inch, mm and pt lengths give the same result as the typed methods.
+func (c Metric) LengthToPx(l Length) int {
+	switch l.Unit {
+	case UnitDp:
+		return c.DpToPx(Dp(l.Value))
+	case UnitSp:
+		return c.SpToPx(Sp(l.Value))
+	case UnitPt:
+		return c.PtToPx(Pt(l.Value))
+	case UnitInch:
+		return c.InchToPx(Inch(l.Value))
+	case UnitMm:
+		return c.MmToPx(Mm(l.Value))
+	default:
+		return roundToInt(float64(l.Value))
+	}
+}
+
+// ConvertLength converts a length to the given unit.
+func (c Metric) ConvertLength(l Length, unit LengthUnit) Length {
+	if l.Unit == unit {
+		return l
+	}
+	if c.FontScaling != FontScalingLinear && (l.Unit == UnitSp || unit == UnitSp) {
+		px := float64(l.Value) * l.Unit.pxPerUnit(c)
+		if l.Unit == UnitSp {
+			px = toPxFloat(c, Sp(l.Value))
+		}
+		if unit == UnitSp {
+			return Length{Value: float32(fromPxFloat[Sp](c, px)), Unit: unit}
+		}
+		return Length{Value: float32(px / unit.pxPerUnit(c)), Unit: unit}
+	}
+	v := float64(l.Value) * l.Unit.pxPerUnit(c) / unit.pxPerUnit(c)
+	return Length{Value: float32(v), Unit: unit}
+}
@@ -0,0 +1,65 @@
+package pxconv
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseLength checks parsing and formatting of lengths.
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Length
+		str      string
+	}{
+		{"16dp", Length{16, UnitDp}, "16dp"},
+		{"16dip", Length{16, UnitDp}, "16dp"},
+		{" 12.5 sp ", Length{12.5, UnitSp}, "12.5sp"},
+		{"-4px", Length{-4, UnitPx}, "-4px"},
+		{"1in", Length{1, UnitInch}, "1in"},
+		{"25.4MM", Length{25.4, UnitMm}, "25.4mm"},
+		{"9pt", Length{9, UnitPt}, "9pt"},
+	}
+
+	for _, test := range tests {
+		l, err := ParseLength(test.in)
+		if err != nil || l != test.expected {
+			t.Errorf("ParseLength(%q) = %v, %v; expected %v", test.in, l, err, test.expected)
+		}
+		if res := l.String(); res != test.str {
+			t.Errorf("ParseLength(%q).String() = %q; expected %q", test.in, res, test.str)
+		}
+	}
+
+	for _, in := range []string{"", "16", "dp", "16em", "1.2.3dp"} {
+		if _, err := ParseLength(in); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("ParseLength(%q) error = %v; expected ErrInvalidLength", in, err)
+		}
+	}
+}
+
+// TestLengthToPx checks that lengths convert like the typed methods.
+func TestLengthToPx(t *testing.T) {
+	m := NewMetric(2, 1.5, 96)
+	tests := []struct {
+		l        Length
+		expected int
+	}{
+		{Length{10, UnitDp}, 20},
+		{Length{10, UnitSp}, 15},
+		{Length{12, UnitPt}, 16},
+		{Length{1, UnitInch}, 96},
+		{Length{25.4, UnitMm}, 96},
+		{Length{7.5, UnitPx}, 8},
+	}
+
+	for _, test := range tests {
+		if res := m.LengthToPx(test.l); res != test.expected {
+			t.Errorf("LengthToPx(%v) = %v; expected %v", test.l, res, test.expected)
+		}
+	}
+
+	if res := m.ConvertLength(Length{12, UnitPt}, UnitDp); res != (Length{8, UnitDp}) {
+		t.Errorf("ConvertLength(12pt, dp) = %v; expected 8dp", res)
+	}
+}
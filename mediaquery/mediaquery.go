@@ -0,0 +1,418 @@
+// Package mediaquery parses and evaluates the subset of CSS media queries that
+// depends on screen metrics: resolution (dpi, dpcm, dppx, x), width and height
+// (px, em, rem), orientation and aspect-ratio, with their min-/max- prefixes
+// and the level 4 range forms such as (width >= 600px), (600px <= width) and
+// (400px <= width < 700px).
+//
+// Queries are evaluated against an Env: a pxconv.Metric plus a viewport size.
+// One dp is one CSS pixel, so the CSS resolution in dppx is Metric.PxPerDp.
+//
+// As in CSS, a query in a list that is malformed or outside the subset is
+// evaluated as "not all" and never matches; the other queries of the list
+// still apply. Media types other than all, screen and print, such as tv, are
+// valid and simply do not match a screen or print Env.
+package mediaquery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+var (
+	// ErrSyntax is returned for a malformed media query.
+	ErrSyntax = errors.New("mediaquery: syntax error")
+	// ErrUnsupported is returned for a feature or unit outside the supported subset.
+	ErrUnsupported = errors.New("mediaquery: unsupported")
+)
+
+// defaultFontSize is the size of 1em in CSS pixels when Env.FontSize is not set.
+const defaultFontSize = 16
+
+// Env describes the device a query is evaluated against.
+type Env struct {
+	// Metric supplies the resolution: PxPerDp device pixels per CSS pixel.
+	Metric pxconv.Metric
+	// Width and Height are the viewport size in dp (CSS pixels).
+	Width, Height pxconv.Dp
+	// FontSize is the size of 1em and 1rem in dp. Zero means 16.
+	FontSize pxconv.Dp
+	// Type is the media type, such as "screen" or "print". Empty means "screen".
+	Type string
+}
+
+// Query is a parsed media query list. It matches if any of its queries matches.
+type Query struct {
+	source  string
+	queries []query
+}
+
+// query is a single media query: an optional media type and a conjunction of features.
+// A query that failed to parse is never, "not all" in CSS terms.
+type query struct {
+	never     bool
+	not       bool
+	mediaType string
+	features  []feature
+}
+
+// feature is a single media feature test, such as (min-width: 600px).
+type feature struct {
+	name  string
+	op    string
+	value float64
+	ident string
+}
+
+// Parse parses a comma-separated media query list. An empty string is a query
+// that matches every device.
+//
+// A query of the list that fails to parse is kept as "not all" and the error is
+// reported, joined with those of the other failing queries. The returned Query
+// is usable even when the error is non-nil, so callers that follow CSS can
+// ignore the error or log it as a warning.
+func Parse(s string) (Query, error) {
+	q := Query{source: s}
+	if strings.TrimSpace(s) == "" {
+		return q, nil
+	}
+	var errs []error
+	for _, part := range strings.Split(s, ",") {
+		mq, err := parseQuery(part)
+		if err != nil {
+			errs = append(errs, err)
+			mq = query{never: true}
+		}
+		q.queries = append(q.queries, mq)
+	}
+	return q, errors.Join(errs...)
+}
+
+// Match parses query and evaluates it against env. As with Parse, the result
+// treats failing queries as "not all" and is meaningful even when the error
+// is non-nil.
+func Match(query string, env Env) (bool, error) {
+	q, err := Parse(query)
+	return q.Match(env), err
+}
+
+// String returns the query as it was passed to Parse.
+func (q Query) String() string {
+	return q.source
+}
+
+// Match reports whether the query applies to env.
+func (q Query) Match(env Env) bool {
+	if len(q.queries) == 0 {
+		return true
+	}
+	for _, mq := range q.queries {
+		if mq.match(env) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mq query) match(env Env) bool {
+	if mq.never {
+		return false
+	}
+	ok := mq.mediaType == "all" || mq.mediaType == env.mediaType()
+	for _, f := range mq.features {
+		ok = ok && f.match(env)
+	}
+	return ok != mq.not
+}
+
+func (f feature) match(env Env) bool {
+	var actual float64
+	switch f.name {
+	case "width":
+		actual = float64(env.Width)
+	case "height":
+		actual = float64(env.Height)
+	case "resolution":
+		actual = float64(density.EnsurePositive(env.Metric.PxPerDp))
+	case "aspect-ratio":
+		if env.Height <= 0 {
+			return false
+		}
+		actual = float64(env.Width) / float64(env.Height)
+	case "orientation":
+		portrait := env.Height >= env.Width
+		return (f.ident == "portrait") == portrait
+	}
+
+	// Lengths are compared in CSS pixels, so em values depend on the environment.
+	value := f.value
+	if f.ident == "em" {
+		value *= float64(env.fontSize())
+	}
+
+	// Env values are float32, so they only match the parsed value to float32
+	// precision: 1.3dppx is 1.2999999523 in a Metric.
+	eps := 1e-6 * math.Max(1, math.Abs(value))
+	switch f.op {
+	case ">=":
+		return actual >= value-eps
+	case "<=":
+		return actual <= value+eps
+	case ">":
+		return actual > value+eps
+	case "<":
+		return actual < value-eps
+	default:
+		return math.Abs(actual-value) <= eps
+	}
+}
+
+func (env Env) mediaType() string {
+	if env.Type == "" {
+		return "screen"
+	}
+	return strings.ToLower(env.Type)
+}
+
+func (env Env) fontSize() pxconv.Dp {
+	if env.FontSize <= 0 {
+		return defaultFontSize
+	}
+	return env.FontSize
+}
+
+// parseQuery parses one media query of a list.
+func parseQuery(s string) (query, error) {
+	mq := query{mediaType: "all"}
+	rest := strings.TrimSpace(strings.ToLower(s))
+	if rest == "" {
+		return mq, fmt.Errorf("%w: empty query in list", ErrSyntax)
+	}
+
+	word, tail := nextWord(rest)
+	switch word {
+	case "not":
+		mq.not = true
+		rest = tail
+	case "only":
+		rest = tail
+	}
+	if rest == "" {
+		return mq, fmt.Errorf("%w: %q without a query", ErrSyntax, word)
+	}
+
+	// An optional media type, then "and"-separated features. Any identifier
+	// other than the keywords is a media type; unknown ones never match.
+	needAnd := false
+	if word, tail = nextWord(rest); word != "" {
+		switch word {
+		case "and", "or", "not", "only", "layer":
+			return mq, fmt.Errorf("%w: %q is not a media type", ErrSyntax, word)
+		}
+		mq.mediaType = word
+		rest = tail
+		needAnd = true
+	}
+
+	for rest != "" {
+		if needAnd {
+			if word, tail = nextWord(rest); word != "and" {
+				return mq, fmt.Errorf("%w: expected \"and\" in %q", ErrSyntax, s)
+			}
+			rest = tail
+		}
+		if !strings.HasPrefix(rest, "(") {
+			return mq, fmt.Errorf("%w: expected \"(\" in %q", ErrSyntax, s)
+		}
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return mq, fmt.Errorf("%w: missing \")\" in %q", ErrSyntax, s)
+		}
+		fs, err := parseFeature(rest[1:end])
+		if err != nil {
+			return mq, err
+		}
+		mq.features = append(mq.features, fs...)
+		rest = strings.TrimSpace(rest[end+1:])
+		needAnd = true
+	}
+	return mq, nil
+}
+
+// nextWord returns the leading identifier of s and the trimmed rest.
+// It returns an empty word if s starts with a parenthesis.
+func nextWord(s string) (string, string) {
+	i := strings.IndexAny(s, " \t\n\r(")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+// parseFeature parses the inside of a parenthesized media feature. A two-sided
+// range such as (400px <= width <= 700px) yields two features.
+func parseFeature(s string) ([]feature, error) {
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		name, op, value := strings.TrimSpace(s[:i]), "=", strings.TrimSpace(s[i+1:])
+		if p, ok := strings.CutPrefix(name, "min-"); ok {
+			name, op = p, ">="
+		} else if p, ok := strings.CutPrefix(name, "max-"); ok {
+			name, op = p, "<="
+		}
+		f, err := newFeature(name, op, value)
+		return []feature{f}, err
+	}
+
+	parts, ops := splitRange(s)
+	switch len(ops) {
+	case 0:
+		return nil, fmt.Errorf("%w: boolean feature (%s)", ErrUnsupported, strings.TrimSpace(s))
+	case 1:
+		// (600px <= width) is (width >= 600px).
+		if !isRangeFeature(parts[0]) && isRangeFeature(parts[1]) {
+			f, err := newFeature(parts[1], reverseOp[ops[0]], parts[0])
+			return []feature{f}, err
+		}
+		f, err := newFeature(parts[0], ops[0], parts[1])
+		return []feature{f}, err
+	case 2:
+		// Both operators must point the same way, as in (400px <= width < 700px).
+		if !(strings.HasPrefix(ops[0], "<") && strings.HasPrefix(ops[1], "<") ||
+			strings.HasPrefix(ops[0], ">") && strings.HasPrefix(ops[1], ">")) {
+			return nil, fmt.Errorf("%w: range (%s)", ErrSyntax, strings.TrimSpace(s))
+		}
+		low, err := newFeature(parts[1], reverseOp[ops[0]], parts[0])
+		if err != nil {
+			return nil, err
+		}
+		high, err := newFeature(parts[1], ops[1], parts[2])
+		return []feature{low, high}, err
+	default:
+		return nil, fmt.Errorf("%w: range (%s)", ErrSyntax, strings.TrimSpace(s))
+	}
+}
+
+// reverseOp maps a comparison to the one that holds with its operands swapped.
+var reverseOp = map[string]string{">=": "<=", "<=": ">=", ">": "<", "<": ">", "=": "="}
+
+// isRangeFeature reports whether name is a feature with a range form.
+func isRangeFeature(name string) bool {
+	switch name {
+	case "width", "height", "resolution", "aspect-ratio":
+		return true
+	}
+	return false
+}
+
+// splitRange splits a range such as "400px <= width < 700px" into its trimmed
+// operands and the comparison operators between them.
+func splitRange(s string) ([]string, []string) {
+	var parts, ops []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' && s[i] != '>' && s[i] != '=' {
+			continue
+		}
+		op := s[i : i+1]
+		if s[i] != '=' && i+1 < len(s) && s[i+1] == '=' {
+			op = s[i : i+2]
+		}
+		parts = append(parts, strings.TrimSpace(s[start:i]))
+		ops = append(ops, op)
+		i += len(op) - 1
+		start = i + 1
+	}
+	return append(parts, strings.TrimSpace(s[start:])), ops
+}
+
+// newFeature builds a feature test from its name, comparison and value.
+func newFeature(name, op, value string) (feature, error) {
+	if value == "" {
+		return feature{}, fmt.Errorf("%w: missing value for %q", ErrSyntax, name)
+	}
+
+	f := feature{name: name, op: op}
+	var err error
+	switch name {
+	case "width", "height":
+		f.value, f.ident, err = parseLength(value)
+	case "resolution":
+		f.value, err = parseResolution(value)
+	case "aspect-ratio":
+		f.value, err = parseRatio(value)
+	case "orientation":
+		if op != "=" || value != "portrait" && value != "landscape" {
+			return f, fmt.Errorf("%w: orientation %q", ErrSyntax, value)
+		}
+		f.ident = value
+	default:
+		return f, fmt.Errorf("%w: feature %q", ErrUnsupported, name)
+	}
+	return f, err
+}
+
+// parseLength parses a width or height in px, em or rem. Em values are returned
+// unscaled with the ident "em" and resolved against the environment.
+func parseLength(s string) (float64, string, error) {
+	num, unit := splitUnit(s)
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: length %q", ErrSyntax, s)
+	}
+	switch unit {
+	case "px":
+		return v, "", nil
+	case "em", "rem":
+		return v, "em", nil
+	case "":
+		if v == 0 {
+			return 0, "", nil
+		}
+	}
+	return 0, "", fmt.Errorf("%w: length unit %q", ErrUnsupported, unit)
+}
+
+// parseResolution parses a resolution and returns it in dppx.
+func parseResolution(s string) (float64, error) {
+	num, unit := splitUnit(s)
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: resolution %q", ErrSyntax, s)
+	}
+	switch unit {
+	case "dppx", "x":
+		return v, nil
+	case "dpi":
+		return v / 96, nil
+	case "dpcm":
+		return v * 2.54 / 96, nil
+	}
+	return 0, fmt.Errorf("%w: resolution unit %q", ErrUnsupported, unit)
+}
+
+// parseRatio parses an aspect ratio such as "16/9" or "1.5".
+func parseRatio(s string) (float64, error) {
+	num, den, found := strings.Cut(s, "/")
+	w, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: aspect ratio %q", ErrSyntax, s)
+	}
+	h := 1.0
+	if found {
+		if h, err = strconv.ParseFloat(strings.TrimSpace(den), 64); err != nil || h <= 0 {
+			return 0, fmt.Errorf("%w: aspect ratio %q", ErrSyntax, s)
+		}
+	}
+	return w / h, nil
+}
+
+// splitUnit splits a dimension such as "600px" into its number and unit.
+func splitUnit(s string) (string, string) {
+	i := strings.LastIndexAny(s, "0123456789.")
+	return s[:i+1], s[i+1:]
+}
@@ -0,0 +1,172 @@
+package mediaquery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestMatch checks evaluation of supported queries against a phone and a desktop.
+func TestMatch(t *testing.T) {
+	phone := Env{Metric: pxconv.NewMetric(3, 3, 480), Width: 412, Height: 915}
+	desktop := Env{Metric: pxconv.NewMetric(1, 1, 96), Width: 1440, Height: 900}
+
+	tests := []struct {
+		query   string
+		phone   bool
+		desktop bool
+	}{
+		{"", true, true},
+		{"screen", true, true},
+		{"print", false, false},
+		{"not print", true, true},
+		{"only screen and (max-width: 600px)", true, false},
+		{"(min-width: 600px) and (max-width: 1500px)", false, true},
+		{"(min-width: 40em)", false, true},
+		{"(max-width: 30rem)", true, false},
+		{"(min-resolution: 2dppx)", true, false},
+		{"(min-resolution: 192dpi)", true, false},
+		{"(resolution: 3x)", true, false},
+		{"(max-resolution: 1x)", false, true},
+		{"(min-resolution: 75.6dpcm)", true, false},
+		{"(orientation: portrait)", true, false},
+		{"(orientation: landscape)", false, true},
+		{"(min-aspect-ratio: 16/10)", false, true},
+		{"(aspect-ratio: 16 / 10)", false, true},
+		{"(max-aspect-ratio: 1)", true, false},
+		{"(width >= 600px)", false, true},
+		{"(width < 412px)", false, false},
+		{"(width <= 412px)", true, false},
+		{"(600px <= width)", false, true},
+		{"(412px > width)", false, false},
+		{"(400px <= width <= 700px)", true, false},
+		{"(1000px < width < 1440px)", false, false},
+		{"(1500px > width >= 1000px)", false, true},
+		{"(2x <= resolution)", true, false},
+		{"(1 < aspect-ratio < 2)", false, true},
+		{"print, (max-width: 500px)", true, false},
+		{"not screen and (min-width: 1000px)", true, false},
+		{"SCREEN AND (MIN-WIDTH: 1000PX)", false, true},
+		{"tv", false, false},
+		{"not speech", true, true},
+		{"tv, (max-width: 600px)", true, false},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.query)
+		if err != nil {
+			t.Errorf("Parse(%q) returned %v", test.query, err)
+			continue
+		}
+		if res := q.Match(phone); res != test.phone {
+			t.Errorf("%q on phone = %v; expected %v", test.query, res, test.phone)
+		}
+		if res := q.Match(desktop); res != test.desktop {
+			t.Errorf("%q on desktop = %v; expected %v", test.query, res, test.desktop)
+		}
+	}
+}
+
+// TestMatchFontSize checks that em lengths follow Env.FontSize.
+func TestMatchFontSize(t *testing.T) {
+	env := Env{Metric: pxconv.NewMetric(1, 1, 96), Width: 600, Height: 800, FontSize: 20}
+
+	if ok, err := Match("(min-width: 30em)", env); err != nil || !ok {
+		t.Errorf("Match((min-width: 30em)) with 20px font = %v, %v; expected true", ok, err)
+	}
+	if ok, err := Match("(min-width: 31em)", env); err != nil || ok {
+		t.Errorf("Match((min-width: 31em)) with 20px font = %v, %v; expected false", ok, err)
+	}
+}
+
+// TestMatchFloat32 checks that float32 densities and viewports that are not
+// exactly representable match the same values written in a query.
+func TestMatchFloat32(t *testing.T) {
+	tests := []struct {
+		env      Env
+		query    string
+		expected bool
+	}{
+		{Env{Metric: pxconv.NewMetric(1.3, 1.3, 208), Width: 400, Height: 800}, "(min-resolution: 1.3dppx)", true},
+		{Env{Metric: pxconv.NewMetric(1.3, 1.3, 208), Width: 400, Height: 800}, "(resolution: 1.3x)", true},
+		{Env{Metric: pxconv.NewMetric(1.3, 1.3, 208), Width: 400, Height: 800}, "(min-resolution: 1.31dppx)", false},
+		{Env{Metric: pxconv.NewMetric(1.1, 1.1, 176), Width: 400, Height: 800}, "(max-resolution: 1.1dppx)", true},
+		{Env{Metric: pxconv.NewMetric(1.1, 1.1, 176), Width: 400, Height: 800}, "(resolution < 1.1x)", false},
+		{Env{Metric: pxconv.NewMetric(2.2, 2.2, 352), Width: 400, Height: 800}, "(resolution: 2.2dppx)", true},
+		{Env{Metric: pxconv.NewMetric(2.2, 2.2, 352), Width: 400, Height: 800}, "(min-resolution: 211.2dpi)", true},
+		{Env{Metric: pxconv.NewMetric(1, 1, 160), Width: 532.8, Height: 800}, "(min-width: 33.3em)", true},
+		{Env{Metric: pxconv.NewMetric(1, 1, 160), Width: 532.8, Height: 800}, "(width < 532.8px)", false},
+		{Env{Metric: pxconv.NewMetric(1, 1, 160), Width: 532.8, Height: 800}, "(max-width: 532.7px)", false},
+	}
+
+	for _, test := range tests {
+		res, err := Match(test.query, test.env)
+		if err != nil || res != test.expected {
+			t.Errorf("Match(%q) at %vdppx, %vdp wide = %v, %v; expected %v", test.query, test.env.Metric.PxPerDp, test.env.Width, res, err, test.expected)
+		}
+	}
+}
+
+// TestParseErrors checks that malformed and unsupported queries are reported.
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected error
+	}{
+		{"not", ErrSyntax},
+		{"screen (min-width: 1px)", ErrSyntax},
+		{"(min-width: 1px", ErrSyntax},
+		{"(min-width: wide)", ErrSyntax},
+		{"(orientation: sideways)", ErrSyntax},
+		{"(400px <= width >= 700px)", ErrSyntax},
+		{"(400px = width = 400px)", ErrSyntax},
+		{"(1px < width < 2px < 3px)", ErrSyntax},
+		{"(width >=)", ErrSyntax},
+		{"(400px <= hover)", ErrUnsupported},
+		{"screen,", ErrSyntax},
+		{"(color)", ErrUnsupported},
+		{"(min-width: 10cm)", ErrUnsupported},
+		{"(hover: hover)", ErrUnsupported},
+		{"and", ErrSyntax},
+		{"(resolution: 2dpx)", ErrUnsupported},
+	}
+
+	for _, test := range tests {
+		if _, err := Parse(test.query); !errors.Is(err, test.expected) {
+			t.Errorf("Parse(%q) error = %v; expected %v", test.query, err, test.expected)
+		}
+	}
+}
+
+// TestParseNotAll checks that failing queries of a list never match while the
+// others still apply.
+func TestParseNotAll(t *testing.T) {
+	phone := Env{Metric: pxconv.NewMetric(3, 3, 480), Width: 412, Height: 915}
+	desktop := Env{Metric: pxconv.NewMetric(1, 1, 96), Width: 1440, Height: 900}
+
+	tests := []struct {
+		query   string
+		phone   bool
+		desktop bool
+		err     error
+	}{
+		{"(hover: hover), (max-width: 600px)", true, false, ErrUnsupported},
+		{"(min-width: wide), (min-width: 1000px)", false, true, ErrSyntax},
+		{"not (color)", false, false, ErrUnsupported},
+		{"screen,", true, true, ErrSyntax},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.query)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Parse(%q) error = %v; expected %v", test.query, err, test.err)
+		}
+		if res := q.Match(phone); res != test.phone {
+			t.Errorf("%q on phone = %v; expected %v", test.query, res, test.phone)
+		}
+		if res, _ := Match(test.query, desktop); res != test.desktop {
+			t.Errorf("Match(%q) on desktop = %v; expected %v", test.query, res, test.desktop)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package pxconv
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+// Emu represents English Metric Units used by OOXML documents (DOCX, PPTX, XLSX).
+// There are 914400 EMU in an inch.
+type Emu int64
+
+// Twip represents a twentieth of a point, used by RTF and WordprocessingML.
+// There are 1440 twips in an inch.
+type Twip int64
+
+// HalfPt represents half-points, used for font sizes in OOXML and RTF.
+// There are 144 half-points in an inch.
+type HalfPt int64
+
+// Himetric represents hundredths of a millimeter, used by OLE and Windows metafiles.
+// There are 2540 HIMETRIC units in an inch.
+type Himetric int64
+
+// Emu converts twips to EMU. The conversion is exact.
+func (v Twip) Emu() Emu {
+	return Emu(v) * consts.EmuPerTwip
+}
+
+// Emu converts half-points to EMU. The conversion is exact.
+func (v HalfPt) Emu() Emu {
+	return Emu(v) * consts.EmuPerHalfPoint
+}
+
+// Emu converts HIMETRIC units to EMU. The conversion is exact.
+func (v Himetric) Emu() Emu {
+	return Emu(v) * consts.EmuPerHimetric
+}
+
+// Twips converts EMU to twips, rounding half away from zero.
+// The result is exact whenever v is a whole number of twips.
+func (v Emu) Twips() Twip {
+	return Twip(divRound(int64(v), consts.EmuPerTwip))
+}
+
+// HalfPts converts EMU to half-points, rounding half away from zero.
+// The result is exact whenever v is a whole number of half-points.
+func (v Emu) HalfPts() HalfPt {
+	return HalfPt(divRound(int64(v), consts.EmuPerHalfPoint))
+}
+
+// Himetric converts EMU to HIMETRIC units, rounding half away from zero.
+// The result is exact whenever v is a whole number of HIMETRIC units.
+func (v Emu) Himetric() Himetric {
+	return Himetric(divRound(int64(v), consts.EmuPerHimetric))
+}
+
+// Twips converts half-points to twips. The conversion is exact.
+func (v HalfPt) Twips() Twip {
+	return v.Emu().Twips()
+}
+
+// Himetric converts twips to HIMETRIC units, rounding half away from zero.
+func (v Twip) Himetric() Himetric {
+	return v.Emu().Himetric()
+}
+
+// Twips converts HIMETRIC units to twips, rounding half away from zero.
+func (v Himetric) Twips() Twip {
+	return v.Emu().Twips()
+}
+
+// Inch converts EMU to inches.
+func (v Emu) Inch() Inch {
+	return Inch(float64(v) / consts.EmuPerInch)
+}
+
+// Mm converts EMU to millimeters.
+func (v Emu) Mm() Mm {
+	return Mm(float64(v) * consts.MmPerInch / consts.EmuPerInch)
+}
+
+// Pt converts EMU to points (1/72 inch).
+func (v Emu) Pt() Pt {
+	return Pt(float64(v) * consts.PointsPerInch / consts.EmuPerInch)
+}
+
+// Emu converts inches to EMU, rounding to the nearest integer.
+func (v Inch) Emu() Emu {
+	return Emu(math.Round(float64(v) * consts.EmuPerInch))
+}
+
+// Emu converts millimeters to EMU, rounding to the nearest integer.
+// For example, Mm(25.4).Emu() returns 914400.
+func (v Mm) Emu() Emu {
+	return Emu(math.Round(float64(v) * consts.EmuPerInch / consts.MmPerInch))
+}
+
+// Emu converts points (1/72 inch) to EMU, rounding to the nearest integer.
+// For example, Pt(1).Emu() returns 12700.
+func (v Pt) Emu() Emu {
+	return Emu(math.Round(float64(v) * consts.EmuPerInch / consts.PointsPerInch))
+}
+
+// EmuToPx converts EMU to pixels using the current DPI.
+// For example, with DPI = 96, EmuToPx(914400) returns 96.
+func (c Metric) EmuToPx(value Emu) int {
+	return int(math.Round(float64(value) * float64(c.Dpi) / consts.EmuPerInch))
+}
+
+// PxToEmu converts pixels to EMU using the current DPI, rounding to the nearest integer.
+// For example, with DPI = 96, PxToEmu(96) returns 914400.
+func (c Metric) PxToEmu(value int) Emu {
+	return Emu(math.Round(float64(value) * consts.EmuPerInch / float64(c.Dpi)))
+}
+
+// TwipToPx converts twips to pixels using the current DPI.
+// For example, with DPI = 96, TwipToPx(1440) returns 96.
+func (c Metric) TwipToPx(value Twip) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToTwip converts pixels to twips using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToTwip(value int) Twip {
+	return Twip(math.Round(float64(value) * consts.EmuPerInch / consts.EmuPerTwip / float64(c.Dpi)))
+}
+
+// HalfPtToPx converts half-points to pixels using the current DPI.
+// For example, with DPI = 96, HalfPtToPx(24) (a 12pt font) returns 16.
+func (c Metric) HalfPtToPx(value HalfPt) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToHalfPt converts pixels to half-points using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToHalfPt(value int) HalfPt {
+	return HalfPt(math.Round(float64(value) * consts.EmuPerInch / consts.EmuPerHalfPoint / float64(c.Dpi)))
+}
+
+// HimetricToPx converts HIMETRIC units to pixels using the current DPI.
+// For example, with DPI = 96, HimetricToPx(2540) returns 96.
+func (c Metric) HimetricToPx(value Himetric) int {
+	return c.EmuToPx(value.Emu())
+}
+
+// PxToHimetric converts pixels to HIMETRIC units using the current DPI, rounding to the nearest integer.
+func (c Metric) PxToHimetric(value int) Himetric {
+	return Himetric(math.Round(float64(value) * consts.EmuPerInch / consts.EmuPerHimetric / float64(c.Dpi)))
+}
+
+// divRound divides a by b (b > 0), rounding half away from zero like math.Round.
+func divRound(a, b int64) int64 {
+	q, r := a/b, a%b
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
@@ -0,0 +1,133 @@
+package pxconv
+
+import "testing"
+
+// TestOfficeUnitsToEmu checks that office units convert to EMU exactly.
+func TestOfficeUnitsToEmu(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      Emu
+		expected Emu
+	}{
+		{"1440 twips", Twip(1440).Emu(), 914400},
+		{"144 half-points", HalfPt(144).Emu(), 914400},
+		{"2540 himetric", Himetric(2540).Emu(), 914400},
+		{"1 twip", Twip(1).Emu(), 635},
+		{"-3 half-points", HalfPt(-3).Emu(), -19050},
+		{"1 himetric", Himetric(1).Emu(), 360},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s = %v EMU; expected %v", test.name, test.got, test.expected)
+		}
+	}
+}
+
+// TestEmuToOfficeUnits checks conversion from EMU with rounding half away from zero.
+func TestEmuToOfficeUnits(t *testing.T) {
+	tests := []struct {
+		emu      Emu
+		twips    Twip
+		halfPts  HalfPt
+		himetric Himetric
+	}{
+		{914400, 1440, 144, 2540},
+		{0, 0, 0, 0},
+		{317, 0, 0, 1},
+		{318, 1, 0, 1},
+		{-318, -1, 0, -1},
+		{3175, 5, 1, 9},
+	}
+
+	for _, test := range tests {
+		if res := test.emu.Twips(); res != test.twips {
+			t.Errorf("Emu(%v).Twips() = %v; expected %v", test.emu, res, test.twips)
+		}
+		if res := test.emu.HalfPts(); res != test.halfPts {
+			t.Errorf("Emu(%v).HalfPts() = %v; expected %v", test.emu, res, test.halfPts)
+		}
+		if res := test.emu.Himetric(); res != test.himetric {
+			t.Errorf("Emu(%v).Himetric() = %v; expected %v", test.emu, res, test.himetric)
+		}
+	}
+}
+
+// TestOfficeUnitsRoundtrip checks that whole values survive a trip through EMU.
+func TestOfficeUnitsRoundtrip(t *testing.T) {
+	for i := int64(-100000); i <= 100000; i += 7 {
+		if res := Twip(i).Emu().Twips(); res != Twip(i) {
+			t.Fatalf("Twip(%v) roundtrip = %v", i, res)
+		}
+		if res := HalfPt(i).Emu().HalfPts(); res != HalfPt(i) {
+			t.Fatalf("HalfPt(%v) roundtrip = %v", i, res)
+		}
+		if res := Himetric(i).Emu().Himetric(); res != Himetric(i) {
+			t.Fatalf("Himetric(%v) roundtrip = %v", i, res)
+		}
+		if res := HalfPt(i).Twips(); res != Twip(i*10) {
+			t.Fatalf("HalfPt(%v).Twips() = %v; expected %v", i, res, i*10)
+		}
+	}
+}
+
+// TestPhysicalUnitsToEmu checks conversion of inches, millimeters and points to EMU.
+func TestPhysicalUnitsToEmu(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      Emu
+		expected Emu
+	}{
+		{"1 inch", Inch(1).Emu(), 914400},
+		{"25.4 mm", Mm(25.4).Emu(), 914400},
+		{"1 mm", Mm(1).Emu(), 36000},
+		{"1 pt", Pt(1).Emu(), 12700},
+		{"72 pt", Pt(72).Emu(), 914400},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s = %v EMU; expected %v", test.name, test.got, test.expected)
+		}
+	}
+
+	if res := Emu(914400).Inch(); res != 1 {
+		t.Errorf("Emu(914400).Inch() = %v; expected 1", res)
+	}
+	if res := Emu(36000).Mm(); res != 1 {
+		t.Errorf("Emu(36000).Mm() = %v; expected 1", res)
+	}
+	if res := Emu(12700).Pt(); res != 1 {
+		t.Errorf("Emu(12700).Pt() = %v; expected 1", res)
+	}
+}
+
+// TestOfficeUnitsToPx checks conversion between office units and pixels.
+func TestOfficeUnitsToPx(t *testing.T) {
+	m := Metric{Dpi: 96}
+
+	if res := m.EmuToPx(914400); res != 96 {
+		t.Errorf("EmuToPx(914400) = %v; expected 96", res)
+	}
+	if res := m.TwipToPx(1440); res != 96 {
+		t.Errorf("TwipToPx(1440) = %v; expected 96", res)
+	}
+	if res := m.HalfPtToPx(24); res != 16 {
+		t.Errorf("HalfPtToPx(24) = %v; expected 16", res)
+	}
+	if res := m.HimetricToPx(2540); res != 96 {
+		t.Errorf("HimetricToPx(2540) = %v; expected 96", res)
+	}
+	if res := m.PxToEmu(96); res != 914400 {
+		t.Errorf("PxToEmu(96) = %v; expected 914400", res)
+	}
+	if res := m.PxToTwip(96); res != 1440 {
+		t.Errorf("PxToTwip(96) = %v; expected 1440", res)
+	}
+	if res := m.PxToHalfPt(16); res != 24 {
+		t.Errorf("PxToHalfPt(16) = %v; expected 24", res)
+	}
+	if res := m.PxToHimetric(96); res != 2540 {
+		t.Errorf("PxToHimetric(96) = %v; expected 2540", res)
+	}
+}
@@ -0,0 +1,123 @@
+package pxconv
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+// PointSystem selects which definition of a typographic point Pt and Pica use.
+type PointSystem uint8
+
+const (
+	// PointsPostScript is the PostScript (DTP) point, also called the big point: 1/72 inch.
+	// It is the zero value, so existing code keeps its behavior.
+	PointsPostScript PointSystem = iota
+	// PointsTeX is the TeX point: 1/72.27 inch.
+	PointsTeX
+)
+
+// String returns the name of the point system.
+func (s PointSystem) String() string {
+	switch s {
+	case PointsTeX:
+		return "tex"
+	default:
+		return "postscript"
+	}
+}
+
+// perInch returns the number of points in one inch for the point system.
+func (s PointSystem) perInch() float64 {
+	switch s {
+	case PointsTeX:
+		return consts.TeXPointsPerInch
+	default:
+		return consts.PointsPerInch
+	}
+}
+
+// Pica represents picas, 12 points each. The point definition follows Metric.Points,
+// so a pica is 1/6 inch by default.
+type Pica float32
+
+// Bp represents big points (PostScript points), always 1/72 inch regardless of Metric.Points.
+type Bp float32
+
+// TexPt represents TeX points, always 1/72.27 inch regardless of Metric.Points.
+type TexPt float32
+
+// Didot represents Didot points as defined by TeX: 1dd = 1238/1157 TeX points (about 0.376 mm).
+type Didot float32
+
+// Cicero represents ciceros, 12 Didot points each.
+type Cicero float32
+
+// WithPoints returns a copy of the Metric that interprets Pt and Pica using the given point system.
+func (c Metric) WithPoints(points PointSystem) Metric {
+	c.Points = points
+	return c
+}
+
+// PicaToPx converts picas to pixels using the current DPI and point system.
+// For example, with DPI = 96 and PostScript points, PicaToPx(6) returns 96.
+func (c Metric) PicaToPx(value Pica) int {
+	return c.perInchToPx(float64(value), c.Points.perInch()/consts.PointsPerPica)
+}
+
+// PxToPica converts pixels to picas using the current DPI and point system.
+func (c Metric) PxToPica(value int) Pica {
+	return Pica(c.pxToPerInch(value, c.Points.perInch()/consts.PointsPerPica))
+}
+
+// BpToPx converts big points to pixels using the current DPI.
+// For example, with DPI = 96, BpToPx(72) returns 96.
+func (c Metric) BpToPx(value Bp) int {
+	return c.perInchToPx(float64(value), consts.PointsPerInch)
+}
+
+// PxToBp converts pixels to big points using the current DPI.
+func (c Metric) PxToBp(value int) Bp {
+	return Bp(c.pxToPerInch(value, consts.PointsPerInch))
+}
+
+// TexPtToPx converts TeX points to pixels using the current DPI.
+// For example, with DPI = 96, TexPtToPx(72.27) returns 96.
+func (c Metric) TexPtToPx(value TexPt) int {
+	return c.perInchToPx(float64(value), consts.TeXPointsPerInch)
+}
+
+// PxToTexPt converts pixels to TeX points using the current DPI.
+func (c Metric) PxToTexPt(value int) TexPt {
+	return TexPt(c.pxToPerInch(value, consts.TeXPointsPerInch))
+}
+
+// DidotToPx converts Didot points to pixels using the current DPI.
+func (c Metric) DidotToPx(value Didot) int {
+	return c.perInchToPx(float64(value), consts.DidotsPerInch)
+}
+
+// PxToDidot converts pixels to Didot points using the current DPI.
+func (c Metric) PxToDidot(value int) Didot {
+	return Didot(c.pxToPerInch(value, consts.DidotsPerInch))
+}
+
+// CiceroToPx converts ciceros to pixels using the current DPI.
+func (c Metric) CiceroToPx(value Cicero) int {
+	return c.perInchToPx(float64(value), consts.DidotsPerInch/consts.DidotsPerCicero)
+}
+
+// PxToCicero converts pixels to ciceros using the current DPI.
+func (c Metric) PxToCicero(value int) Cicero {
+	return Cicero(c.pxToPerInch(value, consts.DidotsPerInch/consts.DidotsPerCicero))
+}
+
+// perInchToPx converts a value of a unit with perInch units per inch to pixels.
+func (c Metric) perInchToPx(value, perInch float64) int {
+	return int(math.Round(value * float64(c.Dpi) / perInch))
+}
+
+// pxToPerInch converts pixels to a unit with perInch units per inch.
+func (c Metric) pxToPerInch(value int, perInch float64) float64 {
+	return float64(value) * perInch / float64(c.Dpi)
+}
@@ -0,0 +1,78 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPointSystemDefault checks that the zero Metric keeps PostScript points.
+func TestPointSystemDefault(t *testing.T) {
+	m := Metric{Dpi: 96}
+	if m.Points != PointsPostScript {
+		t.Fatalf("default Points = %v; expected %v", m.Points, PointsPostScript)
+	}
+	if res := m.PtToPx(72); res != 96 {
+		t.Errorf("PtToPx(72) = %v; expected 96", res)
+	}
+	if res := m.PxToPt(96); res != 72 {
+		t.Errorf("PxToPt(96) = %v; expected 72", res)
+	}
+}
+
+// TestPointSystemTeX checks that Pt follows TeX points when selected.
+func TestPointSystemTeX(t *testing.T) {
+	m := Metric{Dpi: 7227}.WithPoints(PointsTeX)
+	if res := m.PtToPx(72); res != 7200 {
+		t.Errorf("PtToPx(72) = %v; expected 7200", res)
+	}
+	if res := m.PxToPt(7200); math.Abs(float64(res)-72) > 1e-4 {
+		t.Errorf("PxToPt(7200) = %v; expected 72", res)
+	}
+	if res := m.PicaToPx(1); res != 1200 {
+		t.Errorf("PicaToPx(1) = %v; expected 1200", res)
+	}
+}
+
+// TestTypographicUnitsToPx checks conversion of typographic units to pixels.
+func TestTypographicUnitsToPx(t *testing.T) {
+	m := Metric{Dpi: 96}
+	tests := []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{"6 pica", m.PicaToPx(6), 96},
+		{"72 bp", m.BpToPx(72), 96},
+		{"72.27 pt (TeX)", m.TexPtToPx(72.27), 96},
+		{"1157 dd", Metric{Dpi: 72.27}.DidotToPx(1157), 1238},
+		{"1 cc", Metric{Dpi: 72.27 * 100}.CiceroToPx(1), 1284},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%s = %vpx; expected %v", test.name, test.got, test.expected)
+		}
+	}
+}
+
+// TestTypographicUnitsFromPx checks conversion of pixels to typographic units.
+func TestTypographicUnitsFromPx(t *testing.T) {
+	m := Metric{Dpi: 96}
+	tests := []struct {
+		name     string
+		got      float32
+		expected float32
+	}{
+		{"96px in pica", float32(m.PxToPica(96)), 6},
+		{"96px in bp", float32(m.PxToBp(96)), 72},
+		{"96px in TeX pt", float32(m.PxToTexPt(96)), 72.27},
+		{"96px in dd", float32(m.PxToDidot(96)), 67.54152},
+		{"96px in cc", float32(m.PxToCicero(96)), 5.62846},
+	}
+
+	for _, test := range tests {
+		if math.Abs(float64(test.got-test.expected)) > 1e-4 {
+			t.Errorf("%s = %v; expected %v", test.name, test.got, test.expected)
+		}
+	}
+}
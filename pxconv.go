@@ -30,6 +30,10 @@ type Metric struct {
 	PxPerSp float32
 	// Dpi - screen density in dots per inch.
 	Dpi float32
+	// Points selects the definition of Pt. The zero value is the PostScript point.
+	Points PointSystem
+	// FontScaling selects how sp are scaled. The zero value multiplies by PxPerSp.
+	FontScaling FontScaling
 }
 
 // NewMetric creates a new Metric instance, validating input values.
@@ -51,17 +55,28 @@ func (c Metric) DpToPx(value Dp) int {
 }
 
 // SpToPx converts an sp value to pixels, rounding to the nearest integer.
+// With FontScalingNonlinear the value is first mapped to dp through Android's
+// font scale tables.
 func (c Metric) SpToPx(value Sp) int {
+	if t, ok := c.fontTable(); ok {
+		return c.DpToPx(t.spToDp(value))
+	}
 	return int(math.Round(float64(density.EnsurePositive(c.PxPerSp)) * float64(value)))
 }
 
 // DpToSp converts a dp value to sp, using the current density values.
 func (c Metric) DpToSp(value Dp) Sp {
+	if t, ok := c.fontTable(); ok {
+		return t.dpToSp(value)
+	}
 	return Sp(float32(value) * density.EnsurePositive(c.PxPerDp) / density.EnsurePositive(c.PxPerSp))
 }
 
 // SpToDp converts an sp value to dp, using the current density values.
 func (c Metric) SpToDp(value Sp) Dp {
+	if t, ok := c.fontTable(); ok {
+		return t.spToDp(value)
+	}
 	return Dp(float32(value) * density.EnsurePositive(c.PxPerSp) / density.EnsurePositive(c.PxPerDp))
 }
 
@@ -70,8 +85,12 @@ func (c Metric) PxToDp(value int) Dp {
 	return Dp(float32(value) / density.EnsurePositive(c.PxPerDp))
 }
 
-// PxToSp converts a pixel value to sp.
+// PxToSp converts a pixel value to sp. With FontScalingNonlinear it is the
+// inverse of SpToPx before rounding.
 func (c Metric) PxToSp(value int) Sp {
+	if t, ok := c.fontTable(); ok {
+		return t.dpToSp(c.PxToDp(value))
+	}
 	return Sp(float32(value) / density.EnsurePositive(c.PxPerSp))
 }
 
@@ -102,12 +121,12 @@ func (c Metric) PxToMm(value int) Mm {
 // PtToPx converts points to pixels using the current DPI.
 // For example, with DPI = 96, PtToPx(72) returns 96.
 func (c Metric) PtToPx(value Pt) int {
-	return int(math.Round(float64(value) * float64(c.Dpi) / consts.PointsPerInch))
+	return int(math.Round(float64(value) * float64(c.Dpi) / c.Points.perInch()))
 }
 
 // PxToPt converts pixels to points using the current DPI.
 func (c Metric) PxToPt(value int) Pt {
-	return Pt(float32(value) * consts.PointsPerInch / c.Dpi)
+	return Pt(float32(value) * float32(c.Points.perInch()) / c.Dpi)
 }
 
 // GetDensity returns the current density values (PxPerDp and PxPerSp).
@@ -0,0 +1,133 @@
+// Package pxhttp builds a pxconv.Metric for each HTTP request from the client's
+// Client Hints (Sec-CH-DPR, DPR, Sec-CH-Viewport-Width, Viewport-Width and
+// Sec-CH-Width) and carries it through the request context.
+package pxhttp
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+)
+
+// Client Hint header names.
+const (
+	HeaderSecCHDPR           = "Sec-CH-DPR"
+	HeaderDPR                = "DPR"
+	HeaderSecCHViewportWidth = "Sec-CH-Viewport-Width"
+	HeaderViewportWidth      = "Viewport-Width"
+	HeaderSecCHWidth         = "Sec-CH-Width"
+)
+
+// acceptCH lists the hints the Middleware asks clients to send.
+var acceptCH = []string{HeaderSecCHDPR, HeaderSecCHViewportWidth, HeaderSecCHWidth, HeaderDPR, HeaderViewportWidth}
+
+// Hints holds the Client Hints sent with a request. A zero field means the hint
+// was missing or invalid.
+type Hints struct {
+	// DPR is the device pixel ratio: physical pixels per CSS pixel.
+	DPR float32
+	// ViewportWidth is the layout viewport width in CSS pixels.
+	ViewportWidth int
+	// Width is the intended display width of the requested resource in physical pixels.
+	Width int
+}
+
+// ParseHints reads Client Hints from h. The Sec-CH- headers take precedence
+// over their legacy equivalents.
+func ParseHints(h http.Header) Hints {
+	var hints Hints
+	if dpr, ok := parseFloat(h, HeaderSecCHDPR, HeaderDPR); ok {
+		hints.DPR = dpr
+	}
+	if w, ok := parseInt(h, HeaderSecCHViewportWidth, HeaderViewportWidth); ok {
+		hints.ViewportWidth = w
+	}
+	if w, ok := parseInt(h, HeaderSecCHWidth); ok {
+		hints.Width = w
+	}
+	return hints
+}
+
+// Metric builds a Metric from the hints. One dp is one CSS pixel, so PxPerDp is
+// the DPR and Dpi is the DPR times 96 (a CSS pixel is 1/96 inch). PxPerSp keeps
+// the font scale of base, the ratio of its PxPerSp to PxPerDp. Without a DPR
+// hint, base is returned unchanged.
+func (h Hints) Metric(base pxconv.Metric) pxconv.Metric {
+	if h.DPR <= 0 {
+		return base
+	}
+	// Start from base so settings such as Points and FontScaling carry over.
+	m := base
+	m.PxPerDp = h.DPR
+	m.PxPerSp = h.DPR * base.FontScale()
+	m.Dpi = h.DPR * consts.DefaultDpi
+	return m
+}
+
+// MetricFromRequest parses the Client Hints of r and builds a Metric from them.
+func MetricFromRequest(r *http.Request, base pxconv.Metric) pxconv.Metric {
+	return ParseHints(r.Header).Metric(base)
+}
+
+// hintsKey is the context key for Hints.
+type hintsKey struct{}
+
+// WithHints returns a copy of ctx that carries hints.
+func WithHints(ctx context.Context, hints Hints) context.Context {
+	return context.WithValue(ctx, hintsKey{}, hints)
+}
+
+// HintsFrom returns the Hints stored in ctx by WithHints or the Middleware.
+func HintsFrom(ctx context.Context) (Hints, bool) {
+	hints, ok := ctx.Value(hintsKey{}).(Hints)
+	return hints, ok
+}
+
+// Middleware returns HTTP middleware that asks clients for density hints with
+// Accept-CH, marks responses as varying on them, and stores the parsed Hints and
+// the resulting Metric in the request context. Handlers read them with
+// HintsFrom and pxconv.MetricFrom. base supplies the values that hints do not
+// cover, such as the font scale; requests without a DPR hint get base itself.
+func Middleware(base pxconv.Metric) func(http.Handler) http.Handler {
+	accept := strings.Join(acceptCH, ", ")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Accept-CH", accept)
+			for _, name := range acceptCH {
+				w.Header().Add("Vary", name)
+			}
+
+			hints := ParseHints(r.Header)
+			ctx := WithHints(r.Context(), hints)
+			ctx = pxconv.WithMetric(ctx, hints.Metric(base))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// parseFloat returns the first valid positive number among the given headers.
+func parseFloat(h http.Header, names ...string) (float32, bool) {
+	for _, name := range names {
+		v, err := strconv.ParseFloat(strings.TrimSpace(h.Get(name)), 32)
+		if err == nil && v > 0 && !math.IsInf(v, 1) {
+			return float32(v), true
+		}
+	}
+	return 0, false
+}
+
+// parseInt returns the first valid positive integer among the given headers.
+func parseInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		v, err := strconv.Atoi(strings.TrimSpace(h.Get(name)))
+		if err == nil && v > 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package pxhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestParseHints checks header parsing and precedence.
+func TestParseHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected Hints
+	}{
+		{"none", nil, Hints{}},
+		{"sec-ch", map[string]string{"Sec-CH-DPR": "2.625", "Sec-CH-Viewport-Width": "412", "Sec-CH-Width": "800"}, Hints{2.625, 412, 800}},
+		{"legacy", map[string]string{"DPR": "2", "Viewport-Width": "1280"}, Hints{2, 1280, 0}},
+		{"sec-ch wins", map[string]string{"Sec-CH-DPR": "3", "DPR": "2"}, Hints{DPR: 3}},
+		{"invalid sec-ch falls back", map[string]string{"Sec-CH-DPR": "x", "DPR": "1.5"}, Hints{DPR: 1.5}},
+		{"invalid values", map[string]string{"DPR": "-1", "Viewport-Width": "wide", "Sec-CH-Width": "0"}, Hints{}},
+		{"infinite dpr", map[string]string{"DPR": "Inf"}, Hints{}},
+	}
+
+	for _, test := range tests {
+		h := http.Header{}
+		for k, v := range test.headers {
+			h.Set(k, v)
+		}
+		if res := ParseHints(h); res != test.expected {
+			t.Errorf("%s: ParseHints = %+v; expected %+v", test.name, res, test.expected)
+		}
+	}
+}
+
+// TestHintsMetric checks that the Metric follows the DPR and keeps the font scale.
+func TestHintsMetric(t *testing.T) {
+	base := pxconv.NewMetric(1, 1.25, 96)
+
+	m := Hints{DPR: 2}.Metric(base)
+	if m.PxPerDp != 2 || m.PxPerSp != 2.5 || m.Dpi != 192 {
+		t.Errorf("Metric = %+v; expected PxPerDp 2, PxPerSp 2.5, Dpi 192", m)
+	}
+	if res := (Hints{}).Metric(base); res != base {
+		t.Errorf("Metric without DPR = %+v; expected base %+v", res, base)
+	}
+
+	base = base.WithPoints(pxconv.PointsTeX).WithFontScaling(pxconv.FontScalingNonlinear)
+	m = Hints{DPR: 3}.Metric(base)
+	if m.Points != pxconv.PointsTeX || m.FontScaling != pxconv.FontScalingNonlinear {
+		t.Errorf("Metric = %+v; expected Points and FontScaling of base", m)
+	}
+}
+
+// TestMiddleware checks response headers and the request context.
+func TestMiddleware(t *testing.T) {
+	var got pxconv.Metric
+	var hints Hints
+	var ok bool
+	handler := Middleware(pxconv.NewMetric(1, 1, 96))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = pxconv.MetricFrom(r.Context())
+		hints, ok = HintsFrom(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/image.png", nil)
+	req.Header.Set("Sec-CH-DPR", "3")
+	req.Header.Set("Sec-CH-Viewport-Width", "390")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if res := rec.Header().Get("Accept-CH"); res != "Sec-CH-DPR, Sec-CH-Viewport-Width, Sec-CH-Width, DPR, Viewport-Width" {
+		t.Errorf("Accept-CH = %q", res)
+	}
+	if res := rec.Header().Values("Vary"); len(res) != 5 || res[0] != "Sec-CH-DPR" {
+		t.Errorf("Vary = %q", res)
+	}
+	if !ok || hints.DPR != 3 || hints.ViewportWidth != 390 {
+		t.Errorf("HintsFrom = %+v, %v; expected DPR 3 and viewport 390", hints, ok)
+	}
+	if got.DpToPx(10) != 30 || got.Dpi != 288 {
+		t.Errorf("MetricFrom = %+v; expected PxPerDp 3 and Dpi 288", got)
+	}
+}
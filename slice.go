@@ -0,0 +1,159 @@
+package pxconv
+
+import (
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+// The slice methods below convert src into dst element by element and panic if
+// dst is shorter than src. Densities are validated and factors are computed once
+// per call, and the loops are written without bounds checks in the body, so the
+// per-element cost is a multiply and a round. Results are identical to calling
+// the scalar method for each element.
+
+// DpToPxSlice converts dp values to pixels, like DpToPx for each element.
+func (c Metric) DpToPxSlice(dst []int, src []Dp) {
+	f := float64(density.EnsurePositive(c.PxPerDp))
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = roundToInt(f * float64(v))
+	}
+}
+
+// SpToPxSlice converts sp values to pixels, like SpToPx for each element.
+func (c Metric) SpToPxSlice(dst []int, src []Sp) {
+	f := float64(density.EnsurePositive(c.PxPerSp))
+	dst = dst[:len(src)]
+	if t, ok := c.fontTable(); ok {
+		for i, v := range src {
+			dst[i] = c.DpToPx(t.spToDp(v))
+		}
+		return
+	}
+	for i, v := range src {
+		dst[i] = roundToInt(f * float64(v))
+	}
+}
+
+// InchToPxSlice converts inches to pixels, like InchToPx for each element.
+func (c Metric) InchToPxSlice(dst []int, src []Inch) {
+	dpi := float64(c.Dpi)
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = roundToInt(float64(v) * dpi)
+	}
+}
+
+// MmToPxSlice converts millimeters to pixels, like MmToPx for each element.
+func (c Metric) MmToPxSlice(dst []int, src []Mm) {
+	dpi := float64(c.Dpi)
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = roundToInt(float64(v) * dpi / consts.MmPerInch)
+	}
+}
+
+// PtToPxSlice converts points to pixels, like PtToPx for each element.
+func (c Metric) PtToPxSlice(dst []int, src []Pt) {
+	dpi, perInch := float64(c.Dpi), c.Points.perInch()
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = roundToInt(float64(v) * dpi / perInch)
+	}
+}
+
+// PxToDpSlice converts pixels to dp, like PxToDp for each element.
+func (c Metric) PxToDpSlice(dst []Dp, src []int) {
+	f := density.EnsurePositive(c.PxPerDp)
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Dp(float32(v) / f)
+	}
+}
+
+// PxToSpSlice converts pixels to sp, like PxToSp for each element.
+func (c Metric) PxToSpSlice(dst []Sp, src []int) {
+	f := density.EnsurePositive(c.PxPerSp)
+	dst = dst[:len(src)]
+	if t, ok := c.fontTable(); ok {
+		for i, v := range src {
+			dst[i] = t.dpToSp(c.PxToDp(v))
+		}
+		return
+	}
+	for i, v := range src {
+		dst[i] = Sp(float32(v) / f)
+	}
+}
+
+// PxToInchSlice converts pixels to inches, like PxToInch for each element.
+func (c Metric) PxToInchSlice(dst []Inch, src []int) {
+	dpi := c.Dpi
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Inch(float32(v) / dpi)
+	}
+}
+
+// PxToMmSlice converts pixels to millimeters, like PxToMm for each element.
+func (c Metric) PxToMmSlice(dst []Mm, src []int) {
+	dpi := c.Dpi
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Mm(float32(v) * consts.MmPerInch / dpi)
+	}
+}
+
+// PxToPtSlice converts pixels to points, like PxToPt for each element.
+func (c Metric) PxToPtSlice(dst []Pt, src []int) {
+	dpi, perInch := c.Dpi, float32(c.Points.perInch())
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = Pt(float32(v) * perInch / dpi)
+	}
+}
+
+// ToPxSlice converts values of any unit to pixels, like ToPx for each element.
+// It panics if dst is shorter than src.
+func ToPxSlice[F Unit](m Metric, dst []int, src []F) {
+	if sp, ok := any(src).([]Sp); ok && m.FontScaling != FontScalingLinear {
+		m.SpToPxSlice(dst, sp)
+		return
+	}
+	var from F
+	f := from.PxPerUnit(m)
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = roundToInt(float64(v) * f)
+	}
+}
+
+// FromPxSlice converts pixels to any unit, like FromPx for each element.
+// It panics if dst is shorter than src.
+func FromPxSlice[T Unit](m Metric, dst []T, src []int) {
+	if sp, ok := any(dst).([]Sp); ok && m.FontScaling != FontScalingLinear {
+		m.PxToSpSlice(sp, src)
+		return
+	}
+	var to T
+	f := to.PxPerUnit(m)
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = T(float64(v) / f)
+	}
+}
+
+// roundToInt rounds x to the nearest integer, ties away from zero. For every x
+// within the int range it returns the same result as int(math.Round(x)), but
+// uses a truncating conversion and a fix-up instead of math.Round's bit
+// manipulation, which keeps the slice loops short.
+func roundToInt(x float64) int {
+	t := int(x)
+	r := x - float64(t)
+	if r >= 0.5 {
+		t++
+	} else if r <= -0.5 {
+		t--
+	}
+	return t
+}
@@ -0,0 +1,114 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestSliceMatchesScalar checks that slice conversions match the scalar methods.
+func TestSliceMatchesScalar(t *testing.T) {
+	m := NewMetric(2.625, 1.75, 420)
+	n := 2000
+
+	dps, sps := make([]Dp, n), make([]Sp, n)
+	inches, mms, pts := make([]Inch, n), make([]Mm, n), make([]Pt, n)
+	pxs := make([]int, n)
+	for i := 0; i < n; i++ {
+		v := float32(i-n/2) / 8
+		dps[i], sps[i], inches[i], mms[i], pts[i] = Dp(v), Sp(v), Inch(v), Mm(v), Pt(v)
+		pxs[i] = i - n/2
+	}
+
+	out := make([]int, n)
+	m.DpToPxSlice(out, dps)
+	for i, v := range dps {
+		if out[i] != m.DpToPx(v) {
+			t.Fatalf("DpToPxSlice[%d] = %v; DpToPx returns %v", i, out[i], m.DpToPx(v))
+		}
+	}
+	m.SpToPxSlice(out, sps)
+	for i, v := range sps {
+		if out[i] != m.SpToPx(v) {
+			t.Fatalf("SpToPxSlice[%d] = %v; SpToPx returns %v", i, out[i], m.SpToPx(v))
+		}
+	}
+	m.InchToPxSlice(out, inches)
+	for i, v := range inches {
+		if out[i] != m.InchToPx(v) {
+			t.Fatalf("InchToPxSlice[%d] = %v; InchToPx returns %v", i, out[i], m.InchToPx(v))
+		}
+	}
+	m.MmToPxSlice(out, mms)
+	for i, v := range mms {
+		if out[i] != m.MmToPx(v) {
+			t.Fatalf("MmToPxSlice[%d] = %v; MmToPx returns %v", i, out[i], m.MmToPx(v))
+		}
+	}
+	m.PtToPxSlice(out, pts)
+	for i, v := range pts {
+		if out[i] != m.PtToPx(v) {
+			t.Fatalf("PtToPxSlice[%d] = %v; PtToPx returns %v", i, out[i], m.PtToPx(v))
+		}
+	}
+
+	m.PxToDpSlice(dps, pxs)
+	m.PxToSpSlice(sps, pxs)
+	m.PxToInchSlice(inches, pxs)
+	m.PxToMmSlice(mms, pxs)
+	m.PxToPtSlice(pts, pxs)
+	for i, px := range pxs {
+		if dps[i] != m.PxToDp(px) || sps[i] != m.PxToSp(px) || inches[i] != m.PxToInch(px) ||
+			mms[i] != m.PxToMm(px) || pts[i] != m.PxToPt(px) {
+			t.Fatalf("PxTo*Slice mismatch for %vpx", px)
+		}
+	}
+}
+
+// TestGenericSlice checks the generic slice conversions against ToPx and FromPx.
+func TestGenericSlice(t *testing.T) {
+	m := NewMetric(2, 1.5, 96)
+	src := []Pica{0, 1, 2.5, 6}
+	out := make([]int, len(src))
+
+	ToPxSlice(m, out, src)
+	for i, v := range src {
+		if out[i] != ToPx(m, v) {
+			t.Errorf("ToPxSlice[%d] = %v; ToPx returns %v", i, out[i], ToPx(m, v))
+		}
+	}
+
+	back := make([]Pica, len(out))
+	FromPxSlice(m, back, out)
+	for i, px := range out {
+		if back[i] != FromPx[Pica](m, px) {
+			t.Errorf("FromPxSlice[%d] = %v; FromPx returns %v", i, back[i], FromPx[Pica](m, px))
+		}
+	}
+}
+
+// TestSliceShortDst checks that a short destination panics.
+func TestSliceShortDst(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DpToPxSlice with short dst did not panic")
+		}
+	}()
+	NewMetric(1, 1, 96).DpToPxSlice(make([]int, 1), []Dp{1, 2})
+}
+
+// TestPropRoundToInt checks that roundToInt agrees with math.Round within the int range.
+func TestPropRoundToInt(t *testing.T) {
+	for _, x := range []float64{0.5, -0.5, 1.5, -1.5, 0.49999999999999994, -0.49999999999999994, 1 << 52, -(1 << 52) - 0.5} {
+		if got, want := roundToInt(x), int(math.Round(x)); got != want {
+			t.Errorf("roundToInt(%v) = %v; expected %v", x, got, want)
+		}
+	}
+	rapid.Check(t, func(t *rapid.T) {
+		x := rapid.Float64Range(-1e15, 1e15).Draw(t, "x")
+		if got, want := roundToInt(x), int(math.Round(x)); got != want {
+			t.Fatalf("roundToInt(%v) = %v; expected %v", x, got, want)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Package srcset computes the pixel widths needed to render a responsive image
+// and formats the HTML srcset and sizes attributes for it.
+//
+// Image sizes are given in dp, which map one to one to CSS pixels.
+package srcset
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// Widths returns the pixel widths needed to render an image that is base dp
+// wide on each of the metrics, sorted and without duplicates.
+func Widths(base pxconv.Dp, metrics ...pxconv.Metric) []int {
+	widths := make([]int, 0, len(metrics))
+	for _, m := range metrics {
+		widths = append(widths, m.DpToPx(base))
+	}
+	return normalize(widths)
+}
+
+// DensityWidths returns the pixel widths needed to render an image that is base
+// dp wide at each device pixel ratio, sorted and without duplicates. Ratios that
+// are not positive and finite are skipped, as in DensitySrcset.
+func DensityWidths(base pxconv.Dp, ratios ...float32) []int {
+	widths := make([]int, 0, len(ratios))
+	for _, r := range ratios {
+		if !validRatio(r) {
+			continue
+		}
+		widths = append(widths, pxconv.Metric{PxPerDp: r}.DpToPx(base))
+	}
+	return normalize(widths)
+}
+
+// WidthSrcset formats a srcset attribute with w descriptors, such as
+// "hero-640.jpg 640w, hero-1280.jpg 1280w". urlFor returns the URL of the
+// image rendered at the given pixel width. Widths are sorted and deduplicated.
+func WidthSrcset(widths []int, urlFor func(width int) string) string {
+	widths = normalize(append([]int(nil), widths...))
+	candidates := make([]string, 0, len(widths))
+	for _, w := range widths {
+		candidates = append(candidates, escapeURL(urlFor(w))+" "+strconv.Itoa(w)+"w")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// DensitySrcset formats a srcset attribute with x descriptors, such as
+// "icon.png 1x, icon@2x.png 2x", for an image that is base dp wide. urlFor
+// returns the URL of the image rendered at the given pixel width for the given
+// ratio. Ratios are sorted, and ratios that are not positive and finite or
+// repeat are skipped.
+func DensitySrcset(base pxconv.Dp, ratios []float32, urlFor func(width int, ratio float32) string) string {
+	valid := make([]float32, 0, len(ratios))
+	for _, r := range ratios {
+		if validRatio(r) {
+			valid = append(valid, r)
+		}
+	}
+	ratios = valid
+	sort.Slice(ratios, func(i, j int) bool { return ratios[i] < ratios[j] })
+
+	candidates := make([]string, 0, len(ratios))
+	for i, r := range ratios {
+		if i > 0 && r == ratios[i-1] {
+			continue
+		}
+		w := pxconv.Metric{PxPerDp: r}.DpToPx(base)
+		candidates = append(candidates, escapeURL(urlFor(w, r))+" "+strconv.FormatFloat(float64(r), 'f', -1, 32)+"x")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// Size is one entry of a sizes attribute: the image is Width wide when Media
+// matches. An entry without Media always matches, so it ends the list.
+type Size struct {
+	// Media is a media condition, such as "(max-width: 600px)".
+	Media string
+	// Width is a CSS length, such as "100vw" or Px(320).
+	Width string
+}
+
+// Sizes formats a sizes attribute, such as "(max-width: 600px) 100vw, 50vw".
+// fallback is the width used when no media condition matches; it is omitted if empty.
+//
+// Browsers use the first entry without a media condition, so only the last
+// entry may omit Media. An entry without Media becomes the fallback, and the
+// entries after it and fallback itself are dropped because they never apply.
+func Sizes(sizes []Size, fallback string) string {
+	entries := make([]string, 0, len(sizes)+1)
+	for _, s := range sizes {
+		media := strings.TrimSpace(s.Media)
+		if media == "" {
+			return strings.Join(append(entries, strings.TrimSpace(s.Width)), ", ")
+		}
+		entries = append(entries, media+" "+strings.TrimSpace(s.Width))
+	}
+	if fallback = strings.TrimSpace(fallback); fallback != "" {
+		entries = append(entries, fallback)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// Px formats a dp length as CSS pixels, such as "320px".
+func Px(value pxconv.Dp) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32) + "px"
+}
+
+// MaxWidth formats a max-width media condition, such as "(max-width: 600px)".
+func MaxWidth(value pxconv.Dp) string {
+	return "(max-width: " + Px(value) + ")"
+}
+
+// MinWidth formats a min-width media condition, such as "(min-width: 600px)".
+func MinWidth(value pxconv.Dp) string {
+	return "(min-width: " + Px(value) + ")"
+}
+
+// validRatio reports whether r is a usable device pixel ratio. NaN fails the
+// comparison and is rejected too.
+func validRatio(r float32) bool {
+	return r > 0 && !math.IsInf(float64(r), 1)
+}
+
+// normalize sorts widths in place and removes non-positive values and duplicates.
+func normalize(widths []int) []int {
+	sort.Ints(widths)
+	out := widths[:0]
+	for _, w := range widths {
+		if w <= 0 || len(out) > 0 && w == out[len(out)-1] {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// urlEscaper percent-encodes whitespace, which would otherwise end the URL of a
+// candidate, and commas, which would otherwise be read as a candidate separator.
+var urlEscaper = strings.NewReplacer(" ", "%20", "\t", "%09", "\n", "%0A", "\r", "%0D", "\f", "%0C", ",", "%2C")
+
+// escapeURL makes url safe to use as the URL of a srcset candidate.
+func escapeURL(url string) string {
+	return urlEscaper.Replace(url)
+}
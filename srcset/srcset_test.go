@@ -0,0 +1,78 @@
+package srcset
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestWidths checks the widths computed for metrics and ratios.
+func TestWidths(t *testing.T) {
+	metrics := []pxconv.Metric{
+		pxconv.NewMetric(3, 3, 480),
+		pxconv.NewMetric(1, 1, 160),
+		pxconv.NewMetric(2, 2, 320),
+		pxconv.NewMetric(2, 2.5, 320),
+	}
+	if res := Widths(320, metrics...); !reflect.DeepEqual(res, []int{320, 640, 960}) {
+		t.Errorf("Widths = %v; expected [320 640 960]", res)
+	}
+	if res := DensityWidths(100, 2, 1, 1.5, 2, 0); !reflect.DeepEqual(res, []int{100, 150, 200}) {
+		t.Errorf("DensityWidths = %v; expected [100 150 200]", res)
+	}
+	nan := float32(math.NaN())
+	if res := DensityWidths(100, 0, -3, 2, nan); !reflect.DeepEqual(res, []int{200}) {
+		t.Errorf("DensityWidths(0, -3, 2, NaN) = %v; expected [200]", res)
+	}
+	if res := DensityWidths(100, 0); len(res) != 0 {
+		t.Errorf("DensityWidths(0) = %v; expected none", res)
+	}
+}
+
+// TestWidthSrcset checks srcset formatting with w descriptors.
+func TestWidthSrcset(t *testing.T) {
+	res := WidthSrcset([]int{1280, 640, 640}, func(w int) string {
+		return fmt.Sprintf("/img/hero %d.jpg?crop=0,0", w)
+	})
+	expected := "/img/hero%20640.jpg?crop=0%2C0 640w, /img/hero%201280.jpg?crop=0%2C0 1280w"
+	if res != expected {
+		t.Errorf("WidthSrcset = %q; expected %q", res, expected)
+	}
+}
+
+// TestDensitySrcset checks srcset formatting with x descriptors.
+func TestDensitySrcset(t *testing.T) {
+	res := DensitySrcset(24, []float32{3, 1, float32(math.NaN()), 1.5, 1, -2}, func(w int, r float32) string {
+		return fmt.Sprintf("icon-%d.png", w)
+	})
+	expected := "icon-24.png 1x, icon-36.png 1.5x, icon-72.png 3x"
+	if res != expected {
+		t.Errorf("DensitySrcset = %q; expected %q", res, expected)
+	}
+}
+
+// TestSizes checks sizes formatting.
+func TestSizes(t *testing.T) {
+	tests := []struct {
+		sizes    []Size
+		fallback string
+		expected string
+	}{
+		{[]Size{{MaxWidth(600), "100vw"}, {MinWidth(1200), Px(400.5)}}, "50vw", "(max-width: 600px) 100vw, (min-width: 1200px) 400.5px, 50vw"},
+		{nil, "100vw", "100vw"},
+		{[]Size{{"(orientation: portrait)", "90vw"}}, "", "(orientation: portrait) 90vw"},
+		{[]Size{{"", "100vw"}}, "", "100vw"},
+		{[]Size{{MaxWidth(600), "100vw"}, {" ", Px(320)}}, "", "(max-width: 600px) 100vw, 320px"},
+		{[]Size{{"", "100vw"}}, "50vw", "100vw"},
+		{[]Size{{MaxWidth(600), "100vw"}, {"", "80vw"}, {MinWidth(1200), "30vw"}}, "50vw", "(max-width: 600px) 100vw, 80vw"},
+	}
+
+	for _, test := range tests {
+		if res := Sizes(test.sizes, test.fallback); res != test.expected {
+			t.Errorf("Sizes = %q; expected %q", res, test.expected)
+		}
+	}
+}
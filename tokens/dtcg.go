@@ -0,0 +1,200 @@
+package tokens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+var (
+	// ErrBrokenReference is returned when an alias points to a missing token
+	// or to a token that is not a dimension.
+	ErrBrokenReference = errors.New("tokens: broken reference")
+	// ErrCycle is returned when aliases form a cycle.
+	ErrCycle = errors.New("tokens: reference cycle")
+)
+
+// dtcgToken is a token read from a DTCG file before its value is resolved.
+type dtcgToken struct {
+	typ   string
+	value any
+}
+
+// ImportDTCG reads dimension tokens from a W3C Design Tokens Community Group
+// (DTCG) JSON file. Values may be strings such as "16px" or "1.5rem", or objects
+// such as {"value": 16, "unit": "px"}. Aliases such as "{spacing.md}" are
+// resolved, and $type is inherited from enclosing groups and alias targets.
+// Tokens of other types are skipped.
+//
+// DTCG px are CSS pixels, which are dp, and rem are Options.RootFontSize dp.
+// Values are then converted to unit with Options.Metric. Tokens are named by
+// their dot-separated path and returned sorted by name. Broken references,
+// cycles and invalid values are reported with the token path.
+func ImportDTCG(r io.Reader, unit pxconv.LengthUnit, opts Options) ([]Token, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var root map[string]any
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	all := make(map[string]dtcgToken)
+	if err := collect(root, "", "", all); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := make(map[string]pxconv.Length)
+	var out []Token
+	for _, name := range names {
+		l, typ, err := resolveDTCG(name, all, resolved, nil, opts)
+		if err != nil {
+			return nil, err
+		}
+		if typ != "dimension" {
+			continue
+		}
+		out = append(out, Token{Name: name, Value: opts.metric().ConvertLength(l, unit)})
+	}
+	return out, nil
+}
+
+// collect walks a DTCG group and records every token under its path.
+func collect(group map[string]any, path, typ string, all map[string]dtcgToken) error {
+	if t, ok := group["$type"].(string); ok {
+		typ = t
+	}
+	if value, ok := group["$value"]; ok {
+		all[path] = dtcgToken{typ: typ, value: value}
+		return nil
+	}
+	for key, child := range group {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		if strings.ContainsAny(key, ".{}") {
+			return fmt.Errorf("%w: name %q in %q contains '.', '{' or '}'", ErrInvalidToken, key, path)
+		}
+		node, ok := child.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %q is neither a token nor a group", ErrInvalidToken, join(path, key))
+		}
+		if err := collect(node, join(path, key), typ, all); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveDTCG returns the length and type of a token, following aliases.
+// Tokens that are not dimensions are returned with a zero length.
+func resolveDTCG(name string, all map[string]dtcgToken, resolved map[string]pxconv.Length, path []string, opts Options) (pxconv.Length, string, error) {
+	for i, p := range path {
+		if p == name {
+			return pxconv.Length{}, "", fmt.Errorf("%w: %s", ErrCycle, strings.Join(append(path[i:], name), " -> "))
+		}
+	}
+	t := all[name]
+	if l, ok := resolved[name]; ok {
+		return l, "dimension", nil
+	}
+
+	if target, ok := alias(t.value); ok {
+		if _, exists := all[target]; !exists {
+			return pxconv.Length{}, "", fmt.Errorf("%w: %s refers to missing token {%s}", ErrBrokenReference, name, target)
+		}
+		l, typ, err := resolveDTCG(target, all, resolved, append(path, name), opts)
+		if err != nil {
+			return pxconv.Length{}, "", err
+		}
+		if t.typ != "" && t.typ != typ {
+			return pxconv.Length{}, "", fmt.Errorf("%w: %s of type %s refers to {%s} of type %s", ErrBrokenReference, name, t.typ, target, typ)
+		}
+		if typ == "dimension" {
+			resolved[name] = l
+		}
+		return l, typ, nil
+	}
+
+	if t.typ != "dimension" {
+		return pxconv.Length{}, t.typ, nil
+	}
+	l, err := parseDimension(t.value, opts)
+	if err != nil {
+		return pxconv.Length{}, "", fmt.Errorf("%w: %s: %v", ErrInvalidToken, name, err)
+	}
+	resolved[name] = l
+	return l, "dimension", nil
+}
+
+// alias returns the token path of a value that is a single reference such as
+// "{spacing.md}". Values such as "{a} + {b}" are expressions, not aliases.
+func alias(value any) (string, bool) {
+	s, ok := value.(string)
+	if !ok || len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return "", false
+	}
+	target := s[1 : len(s)-1]
+	if strings.ContainsAny(target, "{}") {
+		return "", false
+	}
+	return target, true
+}
+
+// parseDimension parses a DTCG dimension value into a length in dp.
+func parseDimension(value any, opts Options) (pxconv.Length, error) {
+	var num float64
+	var unit string
+	switch v := value.(type) {
+	case string:
+		if strings.ContainsAny(v, "{}") {
+			return pxconv.Length{}, fmt.Errorf("unsupported expression %q", v)
+		}
+		i := strings.LastIndexAny(v, "0123456789.")
+		n, err := json.Number(strings.TrimSpace(v[:i+1])).Float64()
+		if err != nil {
+			return pxconv.Length{}, fmt.Errorf("invalid dimension %q", v)
+		}
+		num, unit = n, strings.TrimSpace(v[i+1:])
+	case map[string]any:
+		n, ok := v["value"].(json.Number)
+		if !ok {
+			return pxconv.Length{}, fmt.Errorf("invalid dimension value %v", v["value"])
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return pxconv.Length{}, fmt.Errorf("invalid dimension value %v", n)
+		}
+		num = f
+		unit, _ = v["unit"].(string)
+	default:
+		return pxconv.Length{}, fmt.Errorf("invalid dimension %v", value)
+	}
+
+	switch unit {
+	case "px":
+	case "rem":
+		num *= float64(opts.rootFontSize())
+	default:
+		return pxconv.Length{}, fmt.Errorf("unsupported unit %q", unit)
+	}
+	return pxconv.Length{Value: float32(num), Unit: pxconv.UnitDp}, nil
+}
+
+// join appends key to a dot-separated token path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
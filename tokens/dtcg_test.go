@@ -0,0 +1,86 @@
+package tokens
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestImportDTCG checks group type inheritance, both value forms, rem and
+// chained aliases, and that non-dimension tokens are skipped.
+func TestImportDTCG(t *testing.T) {
+	f, err := os.Open("testdata/tokens.dtcg.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := ImportDTCG(f, pxconv.UnitDp, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Token{
+		Dp("button.padding", 16),
+		Dp("spacing.gutter", 16),
+		Dp("spacing.lg", 24),
+		Dp("spacing.md", 16),
+		Dp("spacing.xs", 4),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportDTCG = %v; want %v", got, want)
+	}
+}
+
+// TestImportDTCGMetric checks conversion to device pixels and a custom root font size.
+func TestImportDTCGMetric(t *testing.T) {
+	src := `{"size": {"$type": "dimension", "a": {"$value": "10px"}, "b": {"$value": "2rem"}}}`
+	opts := Options{Metric: pxconv.NewMetric(2, 2, 320), RootFontSize: 10}
+	got, err := ImportDTCG(strings.NewReader(src), pxconv.UnitPx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Token{
+		{Name: "size.a", Value: pxconv.Length{Value: 20, Unit: pxconv.UnitPx}},
+		{Name: "size.b", Value: pxconv.Length{Value: 40, Unit: pxconv.UnitPx}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportDTCG = %v; want %v", got, want)
+	}
+}
+
+// TestImportDTCGErrors checks that every failure names the offending token.
+func TestImportDTCGErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  error
+		msg  string
+	}{
+		{"broken", `{"a": {"$type": "dimension", "$value": "{b}"}}`, ErrBrokenReference, "a refers to missing token {b}"},
+		{"wrong type", `{"c": {"$type": "color", "$value": "#fff"}, "a": {"$type": "dimension", "$value": "{c}"}}`, ErrBrokenReference, "a of type dimension"},
+		{"cycle", `{"$type": "dimension", "a": {"$value": "{b}"}, "b": {"$value": "{c}"}, "c": {"$value": "{a}"}}`, ErrCycle, "a -> b -> c -> a"},
+		{"self", `{"$type": "dimension", "a": {"$value": "{a}"}}`, ErrCycle, "a -> a"},
+		{"unit", `{"a": {"$type": "dimension", "$value": "4em"}}`, ErrInvalidToken, `a: unsupported unit "em"`},
+		{"math", `{"$type": "dimension", "a": {"$value": "4px"}, "b": {"$value": "{a} * 2"}}`, ErrInvalidToken, "b: unsupported expression"},
+		{"sum", `{"$type": "dimension", "a": {"$value": "4px"}, "b": {"$value": "{a} + {a}"}}`, ErrInvalidToken, "b: unsupported expression"},
+		{"number", `{"a": {"$type": "dimension", "$value": 4}}`, ErrInvalidToken, "a: invalid dimension"},
+		{"group", `{"a": {"b": 4}}`, ErrInvalidToken, `"a.b" is neither a token nor a group`},
+		{"name", `{"a.b": {"$type": "dimension", "$value": "4px"}}`, ErrInvalidToken, `name "a.b"`},
+		{"json", `{"a":`, ErrInvalidToken, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportDTCG(strings.NewReader(tt.src), pxconv.UnitDp, Options{})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v; want %v", err, tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("err = %q; want it to mention %q", err, tt.msg)
+			}
+		})
+	}
+}
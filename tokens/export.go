@@ -0,0 +1,161 @@
+package tokens
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// generatedHeader marks exported files as generated.
+const generatedHeader = "Code generated by pxconv/tokens. DO NOT EDIT."
+
+// CSSUnit selects the unit of exported CSS and SCSS values.
+type CSSUnit uint8
+
+const (
+	// CSSPx exports values in CSS pixels (one CSS pixel is one dp).
+	CSSPx CSSUnit = iota
+	// CSSRem exports values in rem, relative to Options.RootFontSize.
+	CSSRem
+)
+
+// Options configures the exporters.
+type Options struct {
+	// Metric converts lengths whose unit changes, such as sp, pt or mm to CSS
+	// pixels. The zero value behaves like NewMetric(1, 1, 96) for dp and sp.
+	Metric pxconv.Metric
+	// CSSUnit selects px or rem for WriteCSS and WriteSCSS.
+	CSSUnit CSSUnit
+	// RootFontSize is the size of 1rem in CSS pixels. Zero means 16.
+	RootFontSize float32
+	// Namespace is the Swift enum or Kotlin object that holds the constants. Empty means "Tokens".
+	Namespace string
+	// Package is the Kotlin package. Empty omits the package clause.
+	Package string
+}
+
+// WriteCSS writes the tokens as CSS custom properties on :root, such as
+// "--spacing-md: 16px;".
+func WriteCSS(w io.Writer, tokens []Token, opts Options) error {
+	if err := checkNames(tokens, kebab); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "/* %s */\n\n:root {\n", generatedHeader)
+	for _, t := range tokens {
+		fmt.Fprintf(&b, "  --%s: %s;\n", kebab(t.Name), opts.css(t.Value))
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteSCSS writes the tokens as SCSS variables, such as "$spacing-md: 16px;".
+// Sass variable names cannot start with a digit, so such names are rejected.
+func WriteSCSS(w io.Writer, tokens []Token, opts Options) error {
+	if err := checkNames(tokens, kebab); err != nil {
+		return err
+	}
+	for _, t := range tokens {
+		if name := kebab(t.Name); name[0] >= '0' && name[0] <= '9' {
+			return fmt.Errorf("%w: SCSS variable %q starts with a digit", ErrInvalidToken, name)
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\n", generatedHeader)
+	for _, t := range tokens {
+		fmt.Fprintf(&b, "$%s: %s;\n", kebab(t.Name), opts.css(t.Value))
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteSwift writes the tokens as CGFloat constants in points, which map one to
+// one to dp, inside an enum named by Options.Namespace.
+func WriteSwift(w io.Writer, tokens []Token, opts Options) error {
+	style := func(name string) string { return camel(name, false) }
+	if err := checkNames(tokens, style); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\nimport CoreGraphics\n\npublic enum %s {\n", generatedHeader, opts.namespace())
+	for _, t := range tokens {
+		fmt.Fprintf(&b, "    public static let %s: CGFloat = %s\n", style(t.Name), format(opts.dp(t.Value)))
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteKotlin writes the tokens as Jetpack Compose constants inside an object
+// named by Options.Namespace. Sp tokens become TextUnit values, and every other
+// unit is converted to Dp.
+func WriteKotlin(w io.Writer, tokens []Token, opts Options) error {
+	style := func(name string) string { return camel(name, true) }
+	if err := checkNames(tokens, style); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\n", generatedHeader)
+	if opts.Package != "" {
+		fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+	}
+	b.WriteString("import androidx.compose.ui.unit.dp\nimport androidx.compose.ui.unit.sp\n\n")
+	fmt.Fprintf(&b, "object %s {\n", opts.namespace())
+	for _, t := range tokens {
+		if t.Value.Unit == pxconv.UnitSp {
+			fmt.Fprintf(&b, "    val %s = %s.sp\n", style(t.Name), format(t.Value.Value))
+		} else {
+			fmt.Fprintf(&b, "    val %s = %s.dp\n", style(t.Name), format(opts.dp(t.Value)))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// metric returns the Metric used for conversions, filling in a zero value.
+func (o Options) metric() pxconv.Metric {
+	m := o.Metric
+	if m.Dpi <= 0 {
+		m = pxconv.NewMetric(m.PxPerDp, m.PxPerSp, m.Dpi).WithPoints(m.Points).WithFontScaling(m.FontScaling)
+	}
+	return m
+}
+
+// dp converts a length to dp, which are CSS pixels and iOS points.
+// Px lengths are device pixels and are divided by PxPerDp.
+func (o Options) dp(l pxconv.Length) float32 {
+	return o.metric().ConvertLength(l, pxconv.UnitDp).Value
+}
+
+// css formats a length as a CSS value in the configured unit.
+func (o Options) css(l pxconv.Length) string {
+	v := o.dp(l)
+	if o.CSSUnit == CSSRem {
+		return format(v/o.rootFontSize()) + "rem"
+	}
+	return format(v) + "px"
+}
+
+func (o Options) rootFontSize() float32 {
+	if o.RootFontSize <= 0 {
+		return 16
+	}
+	return o.RootFontSize
+}
+
+func (o Options) namespace() string {
+	if o.Namespace == "" {
+		return "Tokens"
+	}
+	return o.Namespace
+}
+
+// format prints a number without trailing zeros or exponents.
+func format(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
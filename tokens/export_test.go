@@ -0,0 +1,109 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// sampleTokens is the token set used by the golden tests.
+var sampleTokens = []Token{
+	Dp("spacing.xs", 4),
+	Dp("spacing.md", 16),
+	Dp("spacingLg", 24),
+	Sp("font-size_body", 14),
+	Sp("font.size.title", 22.5),
+	{Name: "border.hairline", Value: pxconv.Length{Value: 0.75, Unit: pxconv.UnitPt}},
+	{Name: "2xl", Value: pxconv.Length{Value: 12.7, Unit: pxconv.UnitMm}},
+}
+
+// golden compares got with testdata/name, or rewrites it with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch:\n%s\nexpected\n%s", name, got, want)
+	}
+}
+
+// TestExportGolden checks every exporter against its golden file.
+func TestExportGolden(t *testing.T) {
+	opts := Options{Metric: pxconv.NewMetric(1, 1, 96), Package: "com.example.design"}
+	tests := []struct {
+		file  string
+		write func(io.Writer, []Token, Options) error
+		opts  Options
+	}{
+		{"tokens.css.golden", WriteCSS, opts},
+		{"tokens.rem.css.golden", WriteCSS, Options{CSSUnit: CSSRem}},
+		{"tokens.scss.golden", func(w io.Writer, tokens []Token, opts Options) error {
+			return WriteSCSS(w, tokens[:len(tokens)-1], opts)
+		}, opts},
+		{"tokens.swift.golden", WriteSwift, Options{Namespace: "Spacing"}},
+		{"tokens.kt.golden", WriteKotlin, opts},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := test.write(&b, sampleTokens, test.opts); err != nil {
+			t.Errorf("%s: %v", test.file, err)
+			continue
+		}
+		golden(t, test.file, b.Bytes())
+	}
+}
+
+// TestExportNameCollision checks that names colliding after styling are rejected.
+func TestExportNameCollision(t *testing.T) {
+	tokens := []Token{Dp("spacing.md", 16), Dp("spacing-md", 16)}
+	if err := WriteCSS(io.Discard, tokens, Options{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("WriteCSS error = %v; expected ErrInvalidToken", err)
+	}
+	if err := WriteSCSS(io.Discard, []Token{Dp("2xl", 1)}, Options{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("WriteSCSS error = %v; expected ErrInvalidToken", err)
+	}
+	if err := WriteKotlin(io.Discard, []Token{Dp("--", 1)}, Options{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("WriteKotlin error = %v; expected ErrInvalidToken", err)
+	}
+}
+
+// TestNameStyles checks name conversion for each target.
+func TestNameStyles(t *testing.T) {
+	tests := []struct {
+		name, kebab, camel, pascal string
+	}{
+		{"spacing.md", "spacing-md", "spacingMd", "SpacingMd"},
+		{"fontSizeBody", "font-size-body", "fontSizeBody", "FontSizeBody"},
+		{"icon_24dp", "icon-24dp", "icon24dp", "Icon24dp"},
+		{"2xl", "2xl", "_2xl", "_2xl"},
+	}
+
+	for _, test := range tests {
+		if res := kebab(test.name); res != test.kebab {
+			t.Errorf("kebab(%q) = %q; expected %q", test.name, res, test.kebab)
+		}
+		if res := camel(test.name, false); res != test.camel {
+			t.Errorf("camel(%q) = %q; expected %q", test.name, res, test.camel)
+		}
+		if res := camel(test.name, true); res != test.pascal {
+			t.Errorf("pascal(%q) = %q; expected %q", test.name, res, test.pascal)
+		}
+	}
+}
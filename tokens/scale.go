@@ -0,0 +1,150 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// Common ratios for TypeScale.
+const (
+	MinorSecond     = 1.067
+	MajorSecond     = 1.125
+	MinorThird      = 1.2
+	MajorThird      = 1.25
+	PerfectFourth   = 1.333
+	AugmentedFourth = 1.414
+	PerfectFifth    = 1.5
+	GoldenRatio     = 1.618
+)
+
+// DefaultSpacingSteps are the multipliers of Tailwind's default spacing scale.
+var DefaultSpacingSteps = []float32{
+	0, 0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+	14, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 60, 64, 72, 80, 96,
+}
+
+// DefaultTypeSteps are the names of Tailwind's font sizes, smallest first.
+var DefaultTypeSteps = []string{"xs", "sm", "base", "lg", "xl", "2xl", "3xl", "4xl", "5xl", "6xl"}
+
+// Spacing returns a spacing scale with one dp token per step, in order.
+// Each token is named after its step and is step*unit long, so with a 4dp unit
+// step "2.5" is 10dp.
+func Spacing(unit pxconv.Dp, steps []float32) []Token {
+	out := make([]Token, len(steps))
+	for i, s := range steps {
+		out[i] = Dp(format(s), pxconv.Dp(float64(s)*float64(unit)))
+	}
+	return out
+}
+
+// TypeScale returns a modular type scale with one sp token per name, in order.
+// The token named baseStep is base, and each step above or below it is
+// multiplied or divided by ratio. For example, a 16sp base with MajorThird
+// gives 20sp for the next step and 12.8sp for the previous one.
+func TypeScale(base pxconv.Sp, ratio float64, steps []string, baseStep string) ([]Token, error) {
+	if !(ratio > 0) || math.IsInf(ratio, 0) {
+		return nil, fmt.Errorf("%w: ratio %v", ErrInvalidToken, ratio)
+	}
+	at := -1
+	for i, s := range steps {
+		if s == baseStep {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return nil, fmt.Errorf("%w: base step %q is not in the scale", ErrInvalidToken, baseStep)
+	}
+	out := make([]Token, len(steps))
+	for i, s := range steps {
+		out[i] = Sp(s, pxconv.Sp(float64(base)*math.Pow(ratio, float64(i-at))))
+	}
+	return out, nil
+}
+
+// Snap returns a copy of the tokens with every value rounded to a whole number
+// of device pixels for the Metric, keeping each token's unit. A zero Metric
+// behaves like NewMetric(1, 1, 96).
+func Snap(tokens []Token, m pxconv.Metric) []Token {
+	m = Options{Metric: m}.metric()
+	out := make([]Token, len(tokens))
+	for i, t := range tokens {
+		px := pxconv.Length{Value: float32(m.LengthToPx(t.Value)), Unit: pxconv.UnitPx}
+		out[i] = Token{Name: t.Name, Value: m.ConvertLength(px, t.Value.Unit)}
+	}
+	return out
+}
+
+// WriteTailwind writes the scales as a Tailwind theme.extend object in JSON,
+// with values in rem relative to Options.RootFontSize. Token names are used
+// as keys unchanged and keep their order. Either scale may be empty.
+func WriteTailwind(w io.Writer, spacing, fontSize []Token, opts Options) error {
+	var b bytes.Buffer
+	if err := tailwind(&b, spacing, fontSize, opts); err != nil {
+		return err
+	}
+	b.WriteByte('\n')
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// WriteTailwindConfig is like WriteTailwind but writes a tailwind.config.js
+// module that exports the object.
+func WriteTailwindConfig(w io.Writer, spacing, fontSize []Token, opts Options) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\nmodule.exports = ", generatedHeader)
+	if err := tailwind(&b, spacing, fontSize, opts); err != nil {
+		return err
+	}
+	b.WriteString(";\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// tailwind writes the theme object without a trailing newline.
+func tailwind(b *bytes.Buffer, spacing, fontSize []Token, opts Options) error {
+	same := func(name string) string { return name }
+	for _, scale := range [][]Token{spacing, fontSize} {
+		if err := checkNames(scale, same); err != nil {
+			return err
+		}
+	}
+	opts.CSSUnit = CSSRem
+
+	b.WriteString("{\n  \"theme\": {\n    \"extend\": {")
+	sections := 0
+	for _, s := range []struct {
+		key    string
+		tokens []Token
+	}{{"spacing", spacing}, {"fontSize", fontSize}} {
+		if len(s.tokens) == 0 {
+			continue
+		}
+		if sections > 0 {
+			b.WriteByte(',')
+		}
+		sections++
+		fmt.Fprintf(b, "\n      %q: {", s.key)
+		for i, t := range s.tokens {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, err := json.Marshal(t.Name)
+			if err != nil {
+				return fmt.Errorf("%w: name %q: %v", ErrInvalidToken, t.Name, err)
+			}
+			fmt.Fprintf(b, "\n        %s: %q", key, opts.css(t.Value))
+		}
+		b.WriteString("\n      }")
+	}
+	if sections > 0 {
+		b.WriteString("\n    ")
+	}
+	b.WriteString("}\n  }\n}")
+	return nil
+}
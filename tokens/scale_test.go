@@ -0,0 +1,118 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// TestSpacing checks step names and values of a 4dp scale.
+func TestSpacing(t *testing.T) {
+	res := Spacing(4, []float32{0, 0.5, 2.5, 96})
+	expected := []Token{Dp("0", 0), Dp("0.5", 2), Dp("2.5", 10), Dp("96", 384)}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("Spacing = %v; expected %v", res, expected)
+	}
+	if res := Spacing(4, DefaultSpacingSteps); len(res) != len(DefaultSpacingSteps) {
+		t.Errorf("len(Spacing(DefaultSpacingSteps)) = %d; expected %d", len(res), len(DefaultSpacingSteps))
+	}
+}
+
+// TestTypeScale checks steps above and below the base.
+func TestTypeScale(t *testing.T) {
+	res, err := TypeScale(16, MajorThird, []string{"sm", "base", "lg", "xl"}, "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Token{Sp("sm", 12.8), Sp("base", 16), Sp("lg", 20), Sp("xl", 25)}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("TypeScale = %v; expected %v", res, expected)
+	}
+}
+
+// TestTypeScaleErrors checks that a missing base step and non-positive ratios
+// are rejected.
+func TestTypeScaleErrors(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		base  string
+	}{
+		{MajorThird, "md"},
+		{0, "base"},
+		{-1.2, "base"},
+	}
+
+	for _, test := range tests {
+		if _, err := TypeScale(16, test.ratio, DefaultTypeSteps, test.base); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("TypeScale(16, %v, %q) error = %v; expected ErrInvalidToken", test.ratio, test.base, err)
+		}
+	}
+}
+
+// TestSnap checks that snapped values land on whole device pixels.
+func TestSnap(t *testing.T) {
+	m := pxconv.NewMetric(1.5, 1.5, 240)
+	scale, err := TypeScale(16, MajorThird, []string{"sm", "base", "lg"}, "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Snap(append(scale, Dp("gap", 2.5)), m)
+	// 12.8sp = 19.2px -> 19px, 20sp = 30px, 2.5dp = 3.75px -> 4px.
+	expected := []Token{
+		{Name: "sm", Value: pxconv.Length{Value: float32(19) / 1.5, Unit: pxconv.UnitSp}},
+		Sp("base", 16),
+		Sp("lg", 20),
+		{Name: "gap", Value: pxconv.Length{Value: float32(4) / 1.5, Unit: pxconv.UnitDp}},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("Snap = %v; expected %v", res, expected)
+	}
+	if scale[0].Value.Value != 12.8 {
+		t.Errorf("Snap modified its input: %v", scale[0])
+	}
+}
+
+// TestTailwindGolden checks both Tailwind outputs against golden files.
+func TestTailwindGolden(t *testing.T) {
+	spacing := Spacing(4, []float32{0, 0.5, 1, 4})
+	fontSize, err := TypeScale(16, MajorThird, []string{"sm", "base", "lg", "2xl"}, "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := WriteTailwind(&b, spacing, fontSize, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "tailwind.json.golden", b.Bytes())
+
+	b.Reset()
+	if err := WriteTailwindConfig(&b, spacing, nil, Options{RootFontSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "tailwind.config.js.golden", b.Bytes())
+}
+
+// TestWriteTailwindDuplicate checks that duplicate step names are rejected.
+func TestWriteTailwindDuplicate(t *testing.T) {
+	err := WriteTailwind(&bytes.Buffer{}, []Token{Dp("1", 4), Dp("1", 8)}, nil, Options{})
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("WriteTailwind error = %v; expected ErrInvalidToken", err)
+	}
+}
+
+// TestSnapNonlinear checks that a zero Dpi keeps FontScaling: 30sp renders at
+// 38px at 2x font scale, not 60px.
+func TestSnapNonlinear(t *testing.T) {
+	m := pxconv.Metric{PxPerDp: 1, PxPerSp: 2, FontScaling: pxconv.FontScalingNonlinear}
+	res := Snap([]Token{Sp("title", 30)}, m)
+	if res[0].Value != (pxconv.Length{Value: 30, Unit: pxconv.UnitSp}) {
+		t.Errorf("Snap = %v; expected 30sp", res[0].Value)
+	}
+	if px := (Options{Metric: m}).metric().LengthToPx(res[0].Value); px != 38 {
+		t.Errorf("LengthToPx = %d; expected 38", px)
+	}
+}
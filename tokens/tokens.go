@@ -0,0 +1,100 @@
+// Package tokens keeps design tokens (named lengths) as the single source of
+// truth for spacing and type. It generates spacing and type scales, imports
+// tokens from W3C Design Tokens files, and exports them to CSS, SCSS, Swift,
+// Kotlin and Tailwind.
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MiCkEyZzZ/pxconv"
+)
+
+// ErrInvalidToken is returned for a token with an unusable name or value.
+var ErrInvalidToken = errors.New("tokens: invalid token")
+
+// Token is a named length, such as spacing.md = 16dp.
+type Token struct {
+	// Name is the token name. Words may be separated by dots, dashes,
+	// underscores, spaces or case changes; each exporter applies its own style.
+	Name  string
+	Value pxconv.Length
+}
+
+// Dp returns a token in dp.
+func Dp(name string, value pxconv.Dp) Token {
+	return Token{Name: name, Value: pxconv.Length{Value: float32(value), Unit: pxconv.UnitDp}}
+}
+
+// Sp returns a token in sp.
+func Sp(name string, value pxconv.Sp) Token {
+	return Token{Name: name, Value: pxconv.Length{Value: float32(value), Unit: pxconv.UnitSp}}
+}
+
+// words splits a token name into lower-case words.
+func words(name string) []string {
+	var out []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	return out
+}
+
+// kebab returns the name in kebab-case, such as "spacing-md".
+func kebab(name string) string {
+	return strings.Join(words(name), "-")
+}
+
+// camel returns the name in camelCase, or in PascalCase if upper is set.
+// Names that would start with a digit get a leading underscore.
+func camel(name string, upper bool) string {
+	var b strings.Builder
+	for i, w := range words(name) {
+		if i > 0 || upper {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		b.WriteString(w)
+	}
+	s := b.String()
+	if s != "" && unicode.IsDigit(rune(s[0])) {
+		s = "_" + s
+	}
+	return s
+}
+
+// checkNames reports an error if a token name is empty or two names collide
+// after being converted by style.
+func checkNames(tokens []Token, style func(string) string) error {
+	seen := make(map[string]string, len(tokens))
+	for _, t := range tokens {
+		name := style(t.Name)
+		if name == "" || name == "_" {
+			return fmt.Errorf("%w: name %q", ErrInvalidToken, t.Name)
+		}
+		if prev, dup := seen[name]; dup {
+			return fmt.Errorf("%w: %q and %q both become %q", ErrInvalidToken, prev, t.Name, name)
+		}
+		seen[name] = t.Name
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package pxconv
+
+// Em is letter spacing as a fraction of the font size, as used by Android's
+// letterSpacing and CSS em values.
+type Em float32
+
+// Tracking is letter spacing in thousandths of an em, as used by Photoshop,
+// Illustrator and InDesign.
+type Tracking float32
+
+// Percent is letter spacing as a percentage of the font size, as used by Figma.
+type Percent float32
+
+// Em converts tracking to em. For example, Tracking(50).Em() returns 0.05.
+func (v Tracking) Em() Em {
+	return Em(float64(v) / 1000)
+}
+
+// Percent converts tracking to percent.
+func (v Tracking) Percent() Percent {
+	return Percent(float64(v) / 10)
+}
+
+// Em converts percent to em. For example, Percent(-2).Em() returns -0.02.
+func (v Percent) Em() Em {
+	return Em(float64(v) / 100)
+}
+
+// Tracking converts percent to tracking.
+func (v Percent) Tracking() Tracking {
+	return Tracking(float64(v) * 10)
+}
+
+// Tracking converts em to tracking.
+func (v Em) Tracking() Tracking {
+	return Tracking(float64(v) * 1000)
+}
+
+// Percent converts em to percent.
+func (v Em) Percent() Percent {
+	return Percent(float64(v) * 100)
+}
+
+// LetterSpacingTo converts relative letter spacing to an absolute length in
+// the unit T for text of the given font size. The font size may be in any unit:
+//
+//	px := pxconv.LetterSpacingTo[pxconv.Px](m, pxconv.Percent(5).Em(), pxconv.Sp(16))
+//
+// iOS kerning is in the same points as the font size, so for an iOS font size
+// pass it as the source and request the same type, for example
+// LetterSpacingTo[Dp](m, em, Dp(17)) for a 17pt UIFont.
+//
+// The spacing is a fraction of the rendered font size, so the font size is
+// converted first, following FontScaling, and then scaled.
+func LetterSpacingTo[T, F Unit](m Metric, spacing Em, fontSize F) T {
+	return T(float64(spacing) * float64(To[T](m, fontSize)))
+}
+
+// LetterSpacingFrom converts an absolute letter spacing length to em for text
+// of the given font size. It returns 0 if the font size is zero.
+func LetterSpacingFrom[V, F Unit](m Metric, value V, fontSize F) Em {
+	size := To[V](m, fontSize)
+	if size == 0 {
+		return 0
+	}
+	return Em(float64(value) / float64(size))
+}
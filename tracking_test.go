@@ -0,0 +1,110 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTrackingRelative checks conversions between Em, Tracking and Percent.
+func TestTrackingRelative(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      float32
+		expected float32
+	}{
+		{"Tracking(50).Em", float32(Tracking(50).Em()), 0.05},
+		{"Tracking(-25).Percent", float32(Tracking(-25).Percent()), -2.5},
+		{"Percent(-2).Em", float32(Percent(-2).Em()), -0.02},
+		{"Percent(1.5).Tracking", float32(Percent(1.5).Tracking()), 15},
+		{"Em(0.05).Tracking", float32(Em(0.05).Tracking()), 50},
+		{"Em(0.0125).Percent", float32(Em(0.0125).Percent()), 1.25},
+	}
+
+	for _, test := range tests {
+		if test.res != test.expected {
+			t.Errorf("%s() = %v; expected %v", test.name, test.res, test.expected)
+		}
+	}
+}
+
+// TestLetterSpacingTo checks absolute spacing for fonts sized in sp and pt.
+func TestLetterSpacingTo(t *testing.T) {
+	m := NewMetric(2, 2.5, 320)
+	em := Percent(5).Em()
+	tests := []struct {
+		name     string
+		res      float32
+		expected float32
+	}{
+		// 16sp at 2.5 px/sp is 40px, 5% of that is 2px.
+		{"LetterSpacingTo[Px](5%, 16sp)", float32(LetterSpacingTo[Px](m, em, Sp(16))), 2},
+		// 2px at 2 px/dp is 1dp.
+		{"LetterSpacingTo[Dp](5%, 16sp)", float32(LetterSpacingTo[Dp](m, em, Sp(16))), 1},
+		// A 12pt font: 0.6pt whatever the metric.
+		{"LetterSpacingTo[Pt](5%, 12pt)", float32(LetterSpacingTo[Pt](m, em, Pt(12))), 0.6},
+		// 2px at 320 dpi is 0.45pt.
+		{"LetterSpacingTo[Pt](5%, 16sp)", float32(LetterSpacingTo[Pt](m, em, Sp(16))), 0.45},
+	}
+
+	for _, test := range tests {
+		if math.Abs(float64(test.res-test.expected)) > 1e-5 {
+			t.Errorf("%s = %v; expected %v", test.name, test.res, test.expected)
+		}
+	}
+}
+
+// TestLetterSpacingFrom checks relative spacing from absolute lengths,
+// including a zero font size.
+func TestLetterSpacingFrom(t *testing.T) {
+	m := NewMetric(2, 2.5, 320)
+	tests := []struct {
+		name     string
+		res      float32
+		expected float32
+	}{
+		{"LetterSpacingFrom(2px, 16sp)", float32(LetterSpacingFrom(m, Px(2), Sp(16))), 0.05},
+		{"LetterSpacingFrom(-0.34pt, 17pt).Tracking", float32(LetterSpacingFrom(m, Pt(-0.34), Pt(17)).Tracking()), -20},
+		{"LetterSpacingFrom(2px, 0sp)", float32(LetterSpacingFrom(m, Px(2), Sp(0))), 0},
+	}
+
+	for _, test := range tests {
+		if math.Abs(float64(test.res-test.expected)) > 1e-3 {
+			t.Errorf("%s = %v; expected %v", test.name, test.res, test.expected)
+		}
+	}
+}
+
+// TestLetterSpacingRoundtrip checks To followed by From across units.
+func TestLetterSpacingRoundtrip(t *testing.T) {
+	m := NewMetric(3, 3.3, 480)
+	for _, em := range []Em{-0.05, 0, 0.01, 0.15} {
+		px := LetterSpacingTo[Px](m, em, Sp(14))
+		if res := LetterSpacingFrom(m, px, Sp(14)); math.Abs(float64(res-em)) > 1e-6 {
+			t.Errorf("LetterSpacingFrom(LetterSpacingTo[Px](%v)) = %v; expected %v", em, res, em)
+		}
+		mm := LetterSpacingTo[Mm](m, em, Pt(10))
+		if res := LetterSpacingFrom(m, mm, Pt(10)); math.Abs(float64(res-em)) > 1e-6 {
+			t.Errorf("LetterSpacingFrom(LetterSpacingTo[Mm](%v)) = %v; expected %v", em, res, em)
+		}
+	}
+}
+
+// TestLetterSpacingNonlinear checks that spacing follows the rendered font
+// size under nonlinear font scaling and survives a round trip.
+func TestLetterSpacingNonlinear(t *testing.T) {
+	m := NewMetric(1, 2, 160).WithFontScaling(FontScalingNonlinear)
+	em := Percent(5).Em()
+
+	// 30sp renders at 38dp, so 5% is 1.9dp.
+	dp := LetterSpacingTo[Dp](m, em, Sp(30))
+	if math.Abs(float64(dp)-1.9) > 1e-5 {
+		t.Errorf("LetterSpacingTo[Dp](5%%, 30sp) = %v; expected 1.9", dp)
+	}
+	if res := LetterSpacingFrom(m, dp, Sp(30)); math.Abs(float64(res-em)) > 1e-6 {
+		t.Errorf("LetterSpacingFrom(%v, 30sp) = %v; expected %v", dp, res, em)
+	}
+	px := LetterSpacingTo[Px](m, em, Sp(30))
+	if res := LetterSpacingFrom(m, px, Sp(30)); math.Abs(float64(res-em)) > 1e-6 {
+		t.Errorf("LetterSpacingFrom(%v, 30sp) = %v; expected %v", px, res, em)
+	}
+}
@@ -0,0 +1,130 @@
+package pxconv
+
+import (
+	"math"
+
+	"github.com/MiCkEyZzZ/pxconv/internal/consts"
+	"github.com/MiCkEyZzZ/pxconv/internal/density"
+)
+
+// Px represents fractional pixels. It lets generic code name pixels as a unit,
+// for example To[Px](m, Dp(10)).
+type Px float32
+
+// Unit is the constraint satisfied by every float32 length type in the package.
+// Adding a new unit only takes a new type with a PxPerUnit method.
+type Unit interface {
+	~float32
+	// PxPerUnit returns the number of pixels in one unit of the type under m.
+	// The receiver value is ignored.
+	PxPerUnit(m Metric) float64
+}
+
+// To converts value to the unit T using m. The source unit is inferred from
+// the argument, so only the destination has to be named:
+//
+//	mm := pxconv.To[pxconv.Mm](m, pxconv.Dp(10))
+func To[T, F Unit](m Metric, value F) T {
+	if m.FontScaling != FontScalingLinear {
+		return fromPxFloat[T](m, toPxFloat(m, value))
+	}
+	var from F
+	var to T
+	return T(float64(value) * from.PxPerUnit(m) / to.PxPerUnit(m))
+}
+
+// ToPx converts value to pixels using m, rounding to the nearest integer.
+func ToPx[F Unit](m Metric, value F) int {
+	if m.FontScaling != FontScalingLinear {
+		return int(math.Round(toPxFloat(m, value)))
+	}
+	var from F
+	return int(math.Round(float64(value) * from.PxPerUnit(m)))
+}
+
+// FromPx converts a pixel value to the unit T using m.
+func FromPx[T Unit](m Metric, value int) T {
+	if m.FontScaling != FontScalingLinear {
+		return fromPxFloat[T](m, float64(value))
+	}
+	var to T
+	return T(float64(value) / to.PxPerUnit(m))
+}
+
+// toPxFloat converts value to fractional pixels, applying m's font scaling to Sp.
+func toPxFloat[F Unit](m Metric, value F) float64 {
+	if sp, ok := any(value).(Sp); ok {
+		if t, ok := m.fontTable(); ok {
+			return float64(t.spToDp(sp)) * Dp(0).PxPerUnit(m)
+		}
+	}
+	var from F
+	return float64(value) * from.PxPerUnit(m)
+}
+
+// fromPxFloat converts fractional pixels to the unit T, applying m's font scaling to Sp.
+func fromPxFloat[T Unit](m Metric, px float64) T {
+	var to T
+	if _, ok := any(to).(Sp); ok {
+		if t, ok := m.fontTable(); ok {
+			return T(t.dpToSp(Dp(px / Dp(0).PxPerUnit(m))))
+		}
+	}
+	return T(px / to.PxPerUnit(m))
+}
+
+// PxPerUnit returns 1.
+func (Px) PxPerUnit(Metric) float64 {
+	return 1
+}
+
+// PxPerUnit returns PxPerDp, or 1 if it is not positive.
+func (Dp) PxPerUnit(m Metric) float64 {
+	return float64(density.EnsurePositive(m.PxPerDp))
+}
+
+// PxPerUnit returns PxPerSp, or 1 if it is not positive. This is the linear
+// factor; To, ToPx and FromPx also apply FontScalingNonlinear.
+func (Sp) PxPerUnit(m Metric) float64 {
+	return float64(density.EnsurePositive(m.PxPerSp))
+}
+
+// PxPerUnit returns the number of pixels in one inch.
+func (Inch) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi)
+}
+
+// PxPerUnit returns the number of pixels in one millimeter.
+func (Mm) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) / consts.MmPerInch
+}
+
+// PxPerUnit returns the number of pixels in one point of m's point system.
+func (Pt) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) / m.Points.perInch()
+}
+
+// PxPerUnit returns the number of pixels in one pica of m's point system.
+func (Pica) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) * consts.PointsPerPica / m.Points.perInch()
+}
+
+// PxPerUnit returns the number of pixels in one big point.
+func (Bp) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) / consts.PointsPerInch
+}
+
+// PxPerUnit returns the number of pixels in one TeX point.
+func (TexPt) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) / consts.TeXPointsPerInch
+}
+
+// PxPerUnit returns the number of pixels in one Didot point.
+func (Didot) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) / consts.DidotsPerInch
+}
+
+// PxPerUnit returns the number of pixels in one cicero.
+func (Cicero) PxPerUnit(m Metric) float64 {
+	return float64(m.Dpi) * consts.DidotsPerCicero / consts.DidotsPerInch
+}
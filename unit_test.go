@@ -0,0 +1,61 @@
+package pxconv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTo checks generic conversion between units.
+func TestTo(t *testing.T) {
+	m := NewMetric(2, 1.5, 96)
+	tests := []struct {
+		name     string
+		got      float32
+		expected float32
+	}{
+		{"10dp in px", float32(To[Px](m, Dp(10))), 20},
+		{"10dp in sp", float32(To[Sp](m, Dp(10))), 13.333333},
+		{"96px in inch", float32(To[Inch](m, Px(96))), 1},
+		{"1 inch in mm", float32(To[Mm](m, Inch(1))), 25.4},
+		{"72pt in inch", float32(To[Inch](m, Pt(72))), 1},
+		{"1 pica in pt", float32(To[Pt](m, Pica(1))), 12},
+		{"1 cc in dd", float32(To[Didot](m, Cicero(1))), 12},
+		{"72.27 TeX pt in bp", float32(To[Bp](m, TexPt(72.27))), 72},
+	}
+
+	for _, test := range tests {
+		if math.Abs(float64(test.got-test.expected)) > 1e-4 {
+			t.Errorf("%s = %v; expected %v", test.name, test.got, test.expected)
+		}
+	}
+}
+
+// TestToPxMatchesMethods checks that ToPx agrees with the Metric methods.
+func TestToPxMatchesMethods(t *testing.T) {
+	m := NewMetric(2.625, 1.75, 420)
+
+	for i := 0; i < 1000; i++ {
+		v := float32(i) / 4
+		if got, want := ToPx(m, Dp(v)), m.DpToPx(Dp(v)); got != want {
+			t.Fatalf("ToPx(Dp(%v)) = %v; DpToPx returns %v", v, got, want)
+		}
+		if got, want := ToPx(m, Sp(v)), m.SpToPx(Sp(v)); got != want {
+			t.Fatalf("ToPx(Sp(%v)) = %v; SpToPx returns %v", v, got, want)
+		}
+		if got, want := ToPx(m, Inch(v)), m.InchToPx(Inch(v)); got != want {
+			t.Fatalf("ToPx(Inch(%v)) = %v; InchToPx returns %v", v, got, want)
+		}
+	}
+	if res := FromPx[Dp](m, 21); res != 8 {
+		t.Errorf("FromPx[Dp](21) = %v; expected 8", res)
+	}
+}
+
+// TestToFollowsPointSystem checks that the Pt factor is computed from the Metric.
+func TestToFollowsPointSystem(t *testing.T) {
+	m := NewMetric(1, 1, 96).WithPoints(PointsTeX)
+
+	if res := To[TexPt](m, Pt(10)); res != 10 {
+		t.Errorf("To[TexPt](Pt(10)) = %v; expected 10", res)
+	}
+}